@@ -1,6 +1,8 @@
 package anima
 
 import (
+	"context"
+
 	"github.com/anima-protocol/anima-go/core"
 	"github.com/anima-protocol/anima-go/models"
 	"github.com/anima-protocol/anima-go/protocol"
@@ -9,16 +11,43 @@ import (
 
 // Issue - Issue new credential to Anima Protocol
 func Issue(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest) error {
+	_, err := IssueWithOptions(anima, issuer, request, nil)
+	return err
+}
+
+// IssueWithOptions - Sign and issue credential, returning the signed request and its digests
+//
+// With opts.DryRun the signed request is returned without being sent.
+func IssueWithOptions(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, opts *core.IssuingOptions) (*core.IssuingResult, error) {
 	if err := validators.ValidateProtocol(anima); err != nil {
-		return err
+		return nil, err
+	}
+
+	if err := validators.ValidateIssueRequest(request); err != nil {
+		return nil, err
 	}
 
-	request, err := core.SignIssuing(anima, issuer, request, anima.SigningFunc)
+	if err := validators.ValidateIssuer(issuer); err != nil {
+		return nil, err
+	}
+
+	return issue(context.Background(), anima, issuer, request, opts)
+}
+
+func issue(ctx context.Context, anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, opts *core.IssuingOptions) (*core.IssuingResult, error) {
+	result, err := core.SignIssuingContext(ctx, anima, issuer, request, anima.SigningFunc, opts)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return protocol.Issue(anima, request)
+	if opts != nil && opts.DryRun {
+		return result, nil
+	}
+
+	if err := protocol.IssueContext(ctx, anima, result.Request); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 // Verify - Verify Sharing Request from Anima Protocol
@@ -27,6 +56,10 @@ func Verify(anima *models.Protocol, request *protocol.VerifyRequest) (*protocol.
 		return &protocol.VerifyResponse{}, err
 	}
 
+	if err := validators.ValidateVerifyRequest(request); err != nil {
+		return &protocol.VerifyResponse{}, err
+	}
+
 	return protocol.Verify(anima, request)
 }
 
@@ -36,5 +69,9 @@ func RegisterVerifier(anima *models.Protocol, request *protocol.RegisterVerifier
 		return &protocol.RegisterVerifierResponse{}, err
 	}
 
+	if err := validators.ValidateRegisterVerifierRequest(request); err != nil {
+		return &protocol.RegisterVerifierResponse{}, err
+	}
+
 	return protocol.RegisterVerifier(anima, request)
 }
@@ -0,0 +1,126 @@
+package anima
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/validators"
+)
+
+const DEFAULT_BATCH_WORKERS = 4
+
+// BatchOptions - Options of IssueBatch
+type BatchOptions struct {
+	// Workers - Concurrent issuances, DEFAULT_BATCH_WORKERS when zero
+	Workers int
+	// RateLimit - Maximum issuances started per second, unlimited when zero
+	RateLimit float64
+	// Progress - Called after each item with the number of completed items, from worker goroutines
+	Progress func(done int, total int, result *BatchResult)
+	// Issuing - Options applied to every item
+	Issuing *core.IssuingOptions
+}
+
+// BatchResult - Outcome of one IssueRequest of a batch
+type BatchResult struct {
+	Index  int
+	Result *core.IssuingResult
+	Err    error
+}
+
+// IssueBatch - Issue many requests concurrently over the shared connection
+//
+// Results are ordered as requests. When ctx is cancelled, items not yet
+// started fail with the context error, which is also returned when any item
+// was skipped.
+func IssueBatch(ctx context.Context, anima *models.Protocol, issuer *protocol.AnimaIssuer, requests []*protocol.IssueRequest, opts *BatchOptions) ([]*BatchResult, error) {
+	if err := validators.ValidateProtocol(anima); err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &BatchOptions{}
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DEFAULT_BATCH_WORKERS
+	}
+
+	var limiter <-chan time.Time
+	if opts.RateLimit > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / opts.RateLimit))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	results := make([]*BatchResult, len(requests))
+	jobs := make(chan int)
+
+	var mu sync.Mutex
+	done := 0
+	complete := func(result *BatchResult) {
+		mu.Lock()
+		results[result.Index] = result
+		done++
+		n := done
+		mu.Unlock()
+
+		// Called unlocked so a slow callback does not stall the other workers
+		if opts.Progress != nil {
+			opts.Progress(n, len(requests), result)
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result, err := issue(ctx, anima, issuer, requests[i], opts.Issuing)
+				complete(&BatchResult{Index: i, Result: result, Err: err})
+			}
+		}()
+	}
+
+	next := 0
+dispatch:
+	for ; next < len(requests); next++ {
+		if ctx.Err() != nil {
+			break
+		}
+
+		// The first item starts right away, the limiter spaces the following ones
+		if limiter != nil && next > 0 {
+			select {
+			case <-ctx.Done():
+				break dispatch
+			case <-limiter:
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			break dispatch
+		case jobs <- next:
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if next == len(requests) {
+		return results, nil
+	}
+
+	err := ctx.Err()
+	for ; next < len(requests); next++ {
+		complete(&BatchResult{Index: next, Err: err})
+	}
+
+	return results, err
+}
@@ -0,0 +1,224 @@
+package anima_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	anima "github.com/anima-protocol/anima-go"
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+// batchFixture - Issuer configuration and n membership requests, item i has firstname name-i
+func batchFixture(t *testing.T, n int) (*models.Protocol, *protocol.AnimaIssuer, []*protocol.IssueRequest) {
+	t.Helper()
+	ownerKey, ownerAddress := newKey(t)
+	issuerKey, issuerAddress := newKey(t)
+	issuer := &protocol.AnimaIssuer{Id: "issuer", PublicAddress: issuerAddress, Chain: models.CHAIN_ETH}
+
+	authorization, err := core.CreateIssuingAuthorization(&models.IssuingAuthorization{
+		Specs:       "anima:specs:document/membership@1.0.0",
+		RequestedAt: uint64(time.Now().Unix()),
+		Fields:      map[string]string{},
+		Attributes:  map[string]bool{"firstname": true},
+		Owner:       models.AnimaOwner{ID: "owner", PublicAddress: ownerAddress, Chain: models.CHAIN_ETH},
+		Issuer:      models.AnimaIssuer{ID: issuer.Id, PublicAddress: issuer.PublicAddress, Chain: issuer.Chain},
+	}, evm.PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := make([]*protocol.IssueRequest, n)
+	for i := range requests {
+		requests[i], err = anima.NewIssuance().
+			Document("anima:specs:document/membership@1.0.0").
+			ExpiresAt(time.Now().AddDate(1, 0, 0)).
+			Owner(authorization).
+			Attribute("firstname", fmt.Sprintf("name-%d", i)).
+			Proof(models.PROOF_SPECS_MANUAL_REVIEW, map[string]interface{}{"reviewer": "r", "reviewed_at": time.Now().Unix(), "decision": "approved"}).
+			Build()
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	config := &models.Protocol{Network: models.LOCALNET, Chain: models.CHAIN_ETH, SigningFunc: evm.PrivateKeySigningFunc(issuerKey)}
+	return config, issuer, requests
+}
+
+func TestIssueBatchOrdersResults(t *testing.T) {
+	config, issuer, requests := batchFixture(t, 12)
+	// Item 5 fails validation, the others are still issued
+	requests[5].Proof = nil
+
+	results, err := anima.IssueBatch(context.Background(), config, issuer, requests, &anima.BatchOptions{
+		Workers: 4,
+		Issuing: &core.IssuingOptions{DryRun: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != len(requests) {
+		t.Fatalf("results = %d, want %d", len(results), len(requests))
+	}
+
+	for i, result := range results {
+		if result.Index != i {
+			t.Errorf("results[%d].Index = %d", i, result.Index)
+		}
+
+		if i == 5 {
+			if !errors.Is(result.Err, models.ErrInvalidRequest) || result.Result != nil {
+				t.Errorf("results[5] = %v, %v, want %v", result.Result, result.Err, models.ErrInvalidRequest)
+			}
+			continue
+		}
+
+		if result.Err != nil {
+			t.Errorf("results[%d]: %v", i, result.Err)
+			continue
+		}
+
+		if value := result.Result.Request.Document.Attributes["firstname"].Content.Value; value != fmt.Sprintf("name-%d", i) {
+			t.Errorf("results[%d] issued %s", i, value)
+		}
+	}
+}
+
+func TestIssueBatchProgress(t *testing.T) {
+	config, issuer, requests := batchFixture(t, 10)
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	_, err := anima.IssueBatch(context.Background(), config, issuer, requests, &anima.BatchOptions{
+		Workers: 3,
+		Issuing: &core.IssuingOptions{DryRun: true},
+		Progress: func(done int, total int, result *anima.BatchResult) {
+			mu.Lock()
+			defer mu.Unlock()
+			if total != len(requests) || seen[done] {
+				t.Errorf("progress(%d, %d) repeated or wrong total", done, total)
+			}
+			seen[done] = true
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for done := 1; done <= len(requests); done++ {
+		if !seen[done] {
+			t.Errorf("progress never reported %d items done", done)
+		}
+	}
+}
+
+func TestIssueBatchCancel(t *testing.T) {
+	config, issuer, requests := batchFixture(t, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	results, err := anima.IssueBatch(ctx, config, issuer, requests, &anima.BatchOptions{
+		Workers: 1,
+		Issuing: &core.IssuingOptions{DryRun: true},
+		Progress: func(done int, total int, result *anima.BatchResult) {
+			if done == 2 {
+				cancel()
+			}
+		},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want %v", err, context.Canceled)
+	}
+
+	issued, skipped := 0, 0
+	for i, result := range results {
+		if result == nil || result.Index != i {
+			t.Fatalf("results[%d] = %+v", i, result)
+		}
+
+		switch {
+		case result.Err == nil:
+			issued++
+		case errors.Is(result.Err, context.Canceled):
+			skipped++
+		default:
+			t.Errorf("results[%d]: %v", i, result.Err)
+		}
+	}
+
+	// The item being dispatched while cancelling may still start
+	if issued < 2 || issued > 3 || issued+skipped != len(requests) {
+		t.Errorf("issued = %d, skipped = %d", issued, skipped)
+	}
+}
+
+func TestIssueBatchCancelAfterLastItem(t *testing.T) {
+	config, issuer, requests := batchFixture(t, 3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, err := anima.IssueBatch(ctx, config, issuer, requests, &anima.BatchOptions{
+		Issuing: &core.IssuingOptions{DryRun: true},
+		Progress: func(done int, total int, result *anima.BatchResult) {
+			if done == total {
+				cancel()
+			}
+		},
+	})
+	if err != nil {
+		t.Errorf("err = %v, want nil when no item was skipped", err)
+	}
+}
+
+func TestIssueBatchRateLimit(t *testing.T) {
+	config, issuer, requests := batchFixture(t, 4)
+
+	var mu sync.Mutex
+	var started []time.Duration
+	start := time.Now()
+	signingFunc := config.SigningFunc
+	config.SigningFunc = func(digest []byte) (string, error) {
+		mu.Lock()
+		started = append(started, time.Since(start))
+		mu.Unlock()
+		return signingFunc(digest)
+	}
+
+	_, err := anima.IssueBatch(context.Background(), config, issuer, requests, &anima.BatchOptions{
+		RateLimit: 10,
+		Issuing:   &core.IssuingOptions{DryRun: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	elapsed := time.Since(start)
+	if elapsed < 250*time.Millisecond {
+		t.Errorf("4 items at 10/s took %s, want at least 300ms", elapsed)
+	}
+
+	if len(started) == 0 || started[0] > 80*time.Millisecond {
+		t.Errorf("first item started after %v, want right away", started)
+	}
+}
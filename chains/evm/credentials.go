@@ -1,77 +1,44 @@
 package evm
 
 import (
-	"bytes"
-	"encoding/json"
-
 	"github.com/anima-protocol/anima-go/crypto"
 	"github.com/anima-protocol/anima-go/models"
-	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 func SignCredential(protocol *models.Protocol, credentialContent interface{}, signingFunc func([]byte) (string, error)) (string, error) {
-	message := make(map[string]interface{})
-
-	b, err := json.Marshal(&credentialContent)
+	credentialContentBytes, err := crypto.CanonicalJSON(protocol.SpecsVersion, credentialContent)
 	if err != nil {
 		return "", err
 	}
 
-	credentialContentBytes := new(bytes.Buffer)
-	err = json.Compact(credentialContentBytes, b)
+	c, err := hashTypedData(crypto.Hash(credentialContentBytes))
 	if err != nil {
 		return "", err
 	}
 
-	message["content"] = crypto.Hash(credentialContentBytes.Bytes())
-
-	sigRequest := apitypes.TypedData{
-		Domain: apitypes.TypedDataDomain{
-			Name:    models.PROTOCOL_NAME,
-			Version: models.PROTOCOL_VERSION,
-			ChainId: math.NewHexOrDecimal256(1),
-		},
-		PrimaryType: "Main",
-		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{
-					Name: "name",
-					Type: "string",
-				},
-				{
-					Name: "chainId",
-					Type: "uint256",
-				},
-				{
-					Name: "version",
-					Type: "string",
-				},
-			},
-			"Main": []apitypes.Type{
-				{
-					Name: "content",
-					Type: "string",
-				},
-			},
-		},
-		Message: message,
+	digest, err := GetEIP712Message(c)
+	if err != nil {
+		return "", err
 	}
 
-	c, err := json.Marshal(sigRequest)
+	signature, err := signingFunc(digest)
 	if err != nil {
 		return "", err
 	}
 
-	digest, err := GetEIP712Message(c)
+	return signature, nil
+}
+
+func VerifyCredential(publicAddress string, specsVersion string, credentialContent interface{}, signature string) (bool, error) {
+	credentialContentBytes, err := crypto.CanonicalJSON(specsVersion, credentialContent)
 	if err != nil {
-		return "", err
+		return false, err
 	}
 
-	signature, err := signingFunc(digest)
+	c, err := hashTypedData(crypto.Hash(credentialContentBytes))
 	if err != nil {
-		return "", err
+		return false, err
 	}
 
-	return signature, nil
+	return VerifySignature(publicAddress, c, signature)
 }
@@ -1,32 +1,227 @@
 package evm
 
 import (
+	"bytes"
 	"encoding/json"
+	"math/big"
+	"reflect"
+	"sort"
 
 	"github.com/anima-protocol/anima-go/models"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 type IssuingAuthorizationEIP712 struct {
-	Domain  apitypes.TypedDataDomain    `json:"domain"`
-	Message models.IssuingAuthorization `json:"message"`
-	Types   apitypes.Types              `json:"types"`
+	Domain      apitypes.TypedDataDomain    `json:"domain"`
+	Message     models.IssuingAuthorization `json:"message"`
+	PrimaryType string                      `json:"primaryType,omitempty"`
+	Types       apitypes.Types              `json:"types"`
 }
 
+// GetIssuingAuthorizationEIP712 - Strictly decode an issuing authorization and verify its owner signature
+//
+// The domain and type schema must be exactly the ones NewIssuingAuthorizationEIP712 produces
+// for the decoded message, and the signed digest must match the re-encoded authorization.
 func GetIssuingAuthorizationEIP712(challenge []byte, signature string) (*models.IssuingAuthorization, error) {
 	authorization := IssuingAuthorizationEIP712{}
-	if err := json.Unmarshal(challenge, &authorization); err != nil {
+	decoder := json.NewDecoder(bytes.NewReader(challenge))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&authorization); err != nil {
+		return nil, models.WrapError(models.ErrInvalidRequest, err, "invalid issuing authorization")
+	}
+	if decoder.More() {
+		return nil, models.NewError(models.ErrInvalidRequest, "invalid issuing authorization: trailing data")
+	}
+
+	if err := validateIssuingAuthorizationMessage(&authorization.Message); err != nil {
 		return nil, err
 	}
 
+	expected := NewIssuingAuthorizationEIP712(&authorization.Message)
+	if err := validateIssuingAuthorizationDomain(authorization.Domain, expected.Domain); err != nil {
+		return nil, err
+	}
+
+	if authorization.PrimaryType != "" && authorization.PrimaryType != expected.PrimaryType {
+		return nil, models.NewError(models.ErrInvalidRequest, "invalid issuing authorization primary type %q", authorization.PrimaryType)
+	}
+
+	if err := validateIssuingAuthorizationTypes(authorization.Types, expected.Types); err != nil {
+		return nil, err
+	}
+
+	// The digest of the challenge must be the digest of what was decoded,
+	// otherwise the owner signed something else than the returned message
+	digest, err := GetEIP712Message(challenge)
+	if err != nil {
+		return nil, models.WrapError(models.ErrInvalidRequest, err, "invalid issuing authorization typed data")
+	}
+
+	expectedContent, err := json.Marshal(expected)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedDigest, err := GetEIP712Message(expectedContent)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(digest, expectedDigest) {
+		return nil, models.NewError(models.ErrInvalidRequest, "issuing authorization typed data does not match its message")
+	}
+
 	valid, err := VerifySignature(authorization.Message.Owner.PublicAddress, challenge, signature)
 	if err != nil {
 		return nil, err
 	}
 
 	if !valid {
-		return nil, err
+		return nil, models.NewError(models.ErrBadSignature, "invalid issuing authorization signature")
 	}
 
 	return &authorization.Message, nil
 }
+
+func validateIssuingAuthorizationMessage(message *models.IssuingAuthorization) error {
+	switch {
+	case message.Specs == "":
+		return models.NewError(models.ErrInvalidRequest, "issuing authorization specs is required")
+	case message.RequestedAt == 0:
+		return models.NewError(models.ErrInvalidRequest, "issuing authorization requested_at is required")
+	case message.Owner.ID == "":
+		return models.NewError(models.ErrInvalidRequest, "issuing authorization owner id is required")
+	case !common.IsHexAddress(message.Owner.PublicAddress):
+		return models.NewError(models.ErrInvalidRequest, "invalid issuing authorization owner public_address %q", message.Owner.PublicAddress)
+	case message.Owner.Chain != models.CHAIN_ETH:
+		return models.NewError(models.ErrUnsupportedChain, "unsupported issuing authorization owner chain %q", message.Owner.Chain)
+	case message.Issuer.ID == "":
+		return models.NewError(models.ErrInvalidRequest, "issuing authorization issuer id is required")
+	case !common.IsHexAddress(message.Issuer.PublicAddress):
+		return models.NewError(models.ErrInvalidRequest, "invalid issuing authorization issuer public_address %q", message.Issuer.PublicAddress)
+	}
+	return nil
+}
+
+func validateIssuingAuthorizationDomain(domain apitypes.TypedDataDomain, expected apitypes.TypedDataDomain) error {
+	if domain.Name != expected.Name || domain.Version != expected.Version {
+		return models.NewError(models.ErrInvalidRequest, "invalid issuing authorization domain %s@%s", domain.Name, domain.Version)
+	}
+
+	if domain.ChainId == nil || (*big.Int)(domain.ChainId).Cmp((*big.Int)(expected.ChainId)) != 0 {
+		return models.NewError(models.ErrUnsupportedChain, "invalid issuing authorization domain chain id")
+	}
+
+	if domain.VerifyingContract != "" || domain.Salt != "" {
+		return models.NewError(models.ErrInvalidRequest, "invalid issuing authorization domain")
+	}
+	return nil
+}
+
+func validateIssuingAuthorizationTypes(types apitypes.Types, expected apitypes.Types) error {
+	if len(types) != len(expected) {
+		return models.NewError(models.ErrInvalidRequest, "invalid issuing authorization types")
+	}
+
+	for name, fields := range expected {
+		actual, ok := types[name]
+		if !ok {
+			return models.NewError(models.ErrInvalidRequest, "issuing authorization type %s is missing", name)
+		}
+
+		if !reflect.DeepEqual(actual, fields) {
+			return models.NewError(models.ErrInvalidRequest, "invalid issuing authorization type %s", name)
+		}
+	}
+	return nil
+}
+
+// NewIssuingAuthorizationEIP712 - Typed data an owner signs to authorize issuing
+func NewIssuingAuthorizationEIP712(authorization *models.IssuingAuthorization) *IssuingAuthorizationEIP712 {
+	message := *authorization
+	if message.Fields == nil {
+		message.Fields = map[string]string{}
+	}
+	if message.Attributes == nil {
+		message.Attributes = map[string]bool{}
+	}
+
+	owner := []apitypes.Type{
+		{Name: "id", Type: "string"},
+		{Name: "public_address", Type: "address"},
+		{Name: "chain", Type: "string"},
+	}
+	if message.Owner.Wallet != "" {
+		owner = append(owner, apitypes.Type{Name: "wallet", Type: "string"})
+	}
+	if message.Owner.PublicKeyEncryption != "" {
+		owner = append(owner, apitypes.Type{Name: "public_key_encryption", Type: "string"})
+	}
+
+	fields := []apitypes.Type{}
+	for name := range message.Fields {
+		fields = append(fields, apitypes.Type{Name: name, Type: "string"})
+	}
+	sort.Slice(fields, func(i, j int) bool { return fields[i].Name < fields[j].Name })
+
+	attributes := []apitypes.Type{}
+	for name := range message.Attributes {
+		attributes = append(attributes, apitypes.Type{Name: name, Type: "bool"})
+	}
+	sort.Slice(attributes, func(i, j int) bool { return attributes[i].Name < attributes[j].Name })
+
+	return &IssuingAuthorizationEIP712{
+		Domain: apitypes.TypedDataDomain{
+			Name:    models.PROTOCOL_NAME,
+			Version: models.PROTOCOL_VERSION,
+			ChainId: math.NewHexOrDecimal256(models.CHAIN_ETH_ID),
+		},
+		Message:     message,
+		PrimaryType: "Main",
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{Name: "name", Type: "string"},
+				{Name: "chainId", Type: "uint256"},
+				{Name: "version", Type: "string"},
+			},
+			"Main": []apitypes.Type{
+				{Name: "specs", Type: "string"},
+				{Name: "requested_at", Type: "uint64"},
+				{Name: "fields", Type: "Fields"},
+				{Name: "attributes", Type: "Attributes"},
+				{Name: "owner", Type: "Owner"},
+				{Name: "issuer", Type: "Issuer"},
+			},
+			"Fields":     fields,
+			"Attributes": attributes,
+			"Owner":      owner,
+			"Issuer": []apitypes.Type{
+				{Name: "id", Type: "string"},
+				{Name: "public_address", Type: "address"},
+				{Name: "chain", Type: "string"},
+			},
+		},
+	}
+}
+
+// SignIssuingAuthorizationEIP712 - Sign issuing authorization typed data as its owner
+func SignIssuingAuthorizationEIP712(authorization *models.IssuingAuthorization, signingFunc func([]byte) (string, error)) ([]byte, string, error) {
+	content, err := json.Marshal(NewIssuingAuthorizationEIP712(authorization))
+	if err != nil {
+		return nil, "", err
+	}
+
+	digest, err := GetEIP712Message(content)
+	if err != nil {
+		return nil, "", err
+	}
+
+	signature, err := signingFunc(digest)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return content, signature, nil
+}
@@ -0,0 +1,252 @@
+package evm
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+// newAuthorization - Issuing authorization of a new owner and issuer, with the owner key
+func newAuthorization(t *testing.T) (*models.IssuingAuthorization, *ecdsa.PrivateKey) {
+	t.Helper()
+	ownerKey, ownerAddress := newKey(t)
+	_, issuerAddress := newKey(t)
+	return &models.IssuingAuthorization{
+		Specs:       models.DOCUMENT_SPECS_PASSPORT,
+		RequestedAt: 1717243200,
+		Fields:      map[string]string{"reference": "A-42"},
+		Attributes:  map[string]bool{"lastname": true, "firstname": true, "nationality": false},
+		Owner:       models.AnimaOwner{ID: "owner", PublicAddress: ownerAddress, Chain: models.CHAIN_ETH},
+		Issuer:      models.AnimaIssuer{ID: "issuer", PublicAddress: issuerAddress, Chain: models.CHAIN_ETH},
+	}, ownerKey
+}
+
+func TestIssuingAuthorizationEIP712RoundTrip(t *testing.T) {
+	for name, change := range map[string]func(*models.IssuingAuthorization){
+		"minimal": func(*models.IssuingAuthorization) {},
+		"wallet":  func(a *models.IssuingAuthorization) { a.Owner.Wallet = "metamask" },
+		"encryption key": func(a *models.IssuingAuthorization) {
+			a.Owner.PublicKeyEncryption = "C5YMNdqE4kLgxQhJO1MfuQcHP5hjVSXzamzd/TxlR0U="
+		},
+		"no fields": func(a *models.IssuingAuthorization) { a.Fields = nil },
+	} {
+		t.Run(name, func(t *testing.T) {
+			authorization, ownerKey := newAuthorization(t)
+			change(authorization)
+
+			content, signature, err := SignIssuingAuthorizationEIP712(authorization, PrivateKeySigningFunc(ownerKey))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := GetIssuingAuthorizationEIP712(content, "0x"+signature)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if authorization.Fields == nil {
+				authorization.Fields = map[string]string{}
+			}
+
+			if !reflect.DeepEqual(decoded, authorization) {
+				t.Errorf("decoded = %+v, want %+v", decoded, authorization)
+			}
+		})
+	}
+}
+
+func TestNewIssuingAuthorizationEIP712(t *testing.T) {
+	authorization, _ := newAuthorization(t)
+	typedData := NewIssuingAuthorizationEIP712(authorization)
+
+	if typedData.PrimaryType != "Main" || typedData.Domain.Name != models.PROTOCOL_NAME || typedData.Domain.Version != models.PROTOCOL_VERSION {
+		t.Errorf("domain = %+v, primary type = %s", typedData.Domain, typedData.PrimaryType)
+	}
+
+	// Struct members are sorted by name so the schema does not depend on map order
+	var names []string
+	for _, field := range typedData.Types["Attributes"] {
+		names = append(names, field.Name+":"+field.Type)
+	}
+	if want := []string{"firstname:bool", "lastname:bool", "nationality:bool"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("attributes type = %v, want %v", names, want)
+	}
+
+	if owner := typedData.Types["Owner"]; len(owner) != 3 {
+		t.Errorf("owner type without optional fields = %v", owner)
+	}
+
+	authorization.Owner.Wallet = "metamask"
+	authorization.Owner.PublicKeyEncryption = "key"
+	if owner := NewIssuingAuthorizationEIP712(authorization).Types["Owner"]; len(owner) != 5 || owner[3].Name != "wallet" || owner[4].Name != "public_key_encryption" {
+		t.Errorf("owner type with optional fields = %v", owner)
+	}
+
+	// The input authorization is never changed
+	authorization.Fields = nil
+	NewIssuingAuthorizationEIP712(authorization)
+	if authorization.Fields != nil {
+		t.Error("NewIssuingAuthorizationEIP712 changed its input")
+	}
+}
+
+func TestSignIssuingAuthorizationEIP712RecoversOwner(t *testing.T) {
+	authorization, ownerKey := newAuthorization(t)
+	content, signature, err := SignIssuingAuthorizationEIP712(authorization, PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typedData := map[string]interface{}{}
+	if err := json.Unmarshal(content, &typedData); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"domain", "message", "primaryType", "types"} {
+		if _, ok := typedData[key]; !ok {
+			t.Errorf("typed data has no %s", key)
+		}
+	}
+
+	valid, err := VerifySignature(authorization.Owner.PublicAddress, content, signature)
+	if err != nil || !valid {
+		t.Errorf("owner signature not verified: %t, %v", valid, err)
+	}
+
+	valid, err = VerifySignature(authorization.Issuer.PublicAddress, content, signature)
+	if err == nil && valid {
+		t.Error("signature verified for the issuer")
+	}
+}
+
+// resign - Change the signed typed data of authorization and sign it again as its owner when it still hashes
+func resign(t *testing.T, authorization *models.IssuingAuthorization, ownerKey *ecdsa.PrivateKey, change func(typedData map[string]interface{})) ([]byte, string) {
+	t.Helper()
+	content, signature, err := SignIssuingAuthorizationEIP712(authorization, PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	typedData := map[string]interface{}{}
+	if err := json.Unmarshal(content, &typedData); err != nil {
+		t.Fatal(err)
+	}
+	change(typedData)
+
+	if content, err = json.Marshal(typedData); err != nil {
+		t.Fatal(err)
+	}
+
+	if digest, err := GetEIP712Message(content); err == nil {
+		if signature, err = PrivateKeySigningFunc(ownerKey)(digest); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return content, signature
+}
+
+func TestGetIssuingAuthorizationEIP712Rejects(t *testing.T) {
+	domain := func(typedData map[string]interface{}) map[string]interface{} {
+		return typedData["domain"].(map[string]interface{})
+	}
+	message := func(typedData map[string]interface{}) map[string]interface{} {
+		return typedData["message"].(map[string]interface{})
+	}
+	types := func(typedData map[string]interface{}) map[string]interface{} {
+		return typedData["types"].(map[string]interface{})
+	}
+
+	for _, test := range []struct {
+		name   string
+		change func(typedData map[string]interface{})
+		kind   error
+	}{
+		{"unknown field", func(d map[string]interface{}) { d["extra"] = true }, models.ErrInvalidRequest},
+		{"unknown message field", func(d map[string]interface{}) { message(d)["expires_at"] = 1 }, models.ErrInvalidRequest},
+		{"domain name", func(d map[string]interface{}) { domain(d)["name"] = "Other Protocol" }, models.ErrInvalidRequest},
+		{"domain version", func(d map[string]interface{}) { domain(d)["version"] = "0.9" }, models.ErrInvalidRequest},
+		{"domain chain id", func(d map[string]interface{}) { domain(d)["chainId"] = "0x89" }, models.ErrUnsupportedChain},
+		{"domain verifying contract", func(d map[string]interface{}) {
+			domain(d)["verifyingContract"] = "0x0000000000000000000000000000000000000001"
+		}, models.ErrInvalidRequest},
+		{"primary type", func(d map[string]interface{}) { d["primaryType"] = "Owner" }, models.ErrInvalidRequest},
+		{"missing type", func(d map[string]interface{}) { delete(types(d), "Issuer") }, models.ErrInvalidRequest},
+		{"extra type", func(d map[string]interface{}) { types(d)["Extra"] = []interface{}{} }, models.ErrInvalidRequest},
+		{"type field", func(d map[string]interface{}) {
+			types(d)["Main"].([]interface{})[1].(map[string]interface{})["type"] = "uint256"
+		}, models.ErrInvalidRequest},
+		{"unsigned attribute", func(d map[string]interface{}) {
+			attributes := types(d)["Attributes"].([]interface{})
+			types(d)["Attributes"] = attributes[:len(attributes)-1]
+		}, models.ErrInvalidRequest},
+		{"owner chain", func(d map[string]interface{}) {
+			message(d)["owner"].(map[string]interface{})["chain"] = "SOL"
+		}, models.ErrUnsupportedChain},
+		// Decoding matches keys case-insensitively but hashing does not, so
+		// the owner would have signed a message without specs
+		{"case folded message key", func(d map[string]interface{}) {
+			message(d)["SPECS"] = message(d)["specs"]
+			delete(message(d), "specs")
+		}, models.ErrInvalidRequest},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			authorization, ownerKey := newAuthorization(t)
+			content, signature := resign(t, authorization, ownerKey, test.change)
+
+			decoded, err := GetIssuingAuthorizationEIP712(content, signature)
+			if decoded != nil || !errors.Is(err, test.kind) {
+				t.Errorf("decoded = %+v, err = %v, want %v", decoded, err, test.kind)
+			}
+		})
+	}
+}
+
+func TestGetIssuingAuthorizationEIP712RejectsSignature(t *testing.T) {
+	authorization, ownerKey := newAuthorization(t)
+	otherKey, _ := newKey(t)
+	content, signature, err := SignIssuingAuthorizationEIP712(authorization, PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, otherSignature, err := SignIssuingAuthorizationEIP712(authorization, PrivateKeySigningFunc(otherKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorization.RequestedAt++
+	otherContent, _, err := SignIssuingAuthorizationEIP712(authorization, PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, test := range map[string]struct {
+		content   []byte
+		signature string
+	}{
+		"other signer":    {content, otherSignature},
+		"other challenge": {otherContent, signature},
+		"empty":           {content, ""},
+		"not hex":         {content, "0xzz"},
+		"truncated":       {content, signature[:64]},
+	} {
+		decoded, err := GetIssuingAuthorizationEIP712(test.content, test.signature)
+		if decoded != nil || !errors.Is(err, models.ErrBadSignature) {
+			t.Errorf("%s: decoded = %+v, err = %v, want %v", name, decoded, err, models.ErrBadSignature)
+		}
+	}
+}
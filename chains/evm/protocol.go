@@ -1,70 +1,71 @@
 package evm
 
 import (
-	"encoding/json"
-
 	"github.com/anima-protocol/anima-go/crypto"
 	"github.com/anima-protocol/anima-go/models"
-	"github.com/ethereum/go-ethereum/common/math"
-	"github.com/ethereum/go-ethereum/signer/core/apitypes"
 )
 
 func SignProtocolRequest(protocol *models.Protocol, req interface{}, signingFunc func([]byte) (string, error)) (string, error) {
-	message := make(map[string]interface{})
+	digest, err := LegacyProtocolRequestDigest(protocol.SpecsVersion, req)
+	if err != nil {
+		return "", err
+	}
 
-	b, err := json.Marshal(req)
+	signature, err := signingFunc(digest)
 	if err != nil {
 		return "", err
 	}
 
-	message["content"] = crypto.Hash(b)
+	return signature, nil
+}
 
-	sigRequest := apitypes.TypedData{
-		Domain: apitypes.TypedDataDomain{
-			Name:    models.PROTOCOL_NAME,
-			Version: models.PROTOCOL_VERSION,
-			ChainId: math.NewHexOrDecimal256(1),
-		},
-		PrimaryType: "Main",
-		Types: apitypes.Types{
-			"EIP712Domain": []apitypes.Type{
-				{
-					Name: "name",
-					Type: "string",
-				},
-				{
-					Name: "chainId",
-					Type: "uint256",
-				},
-				{
-					Name: "version",
-					Type: "string",
-				},
-			},
-			"Main": []apitypes.Type{
-				{
-					Name: "content",
-					Type: "string",
-				},
-			},
-		},
-		Message: message,
+// LegacyProtocolRequestDigest - EIP-712 digest of a protocol request body alone, as signed by SignProtocolRequest
+func LegacyProtocolRequestDigest(specsVersion string, req interface{}) ([]byte, error) {
+	b, err := crypto.CanonicalJSON(specsVersion, req)
+	if err != nil {
+		return nil, err
 	}
 
-	c, err := json.Marshal(sigRequest)
+	c, err := hashTypedData(crypto.Hash(b))
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	return GetEIP712Message(c)
+}
+
+// ProtocolRequestDigest - EIP-712 digest of a protocol request body bound to its replay protection claims
+func ProtocolRequestDigest(specsVersion string, req interface{}, claims *models.ProtocolRequestClaims) ([]byte, error) {
+	body, err := crypto.CanonicalJSON(specsVersion, req)
+	if err != nil {
+		return nil, err
 	}
 
-	digest, err := GetEIP712Message(c)
+	envelope, err := crypto.CanonicalJSON(specsVersion, map[string]interface{}{
+		"body":      crypto.Hash(body),
+		"method":    claims.Method,
+		"network":   claims.Network,
+		"timestamp": claims.Timestamp,
+		"nonce":     claims.Nonce,
+	})
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	signature, err := signingFunc(digest)
+	c, err := hashTypedData(crypto.Hash(envelope))
+	if err != nil {
+		return nil, err
+	}
+
+	return GetEIP712Message(c)
+}
+
+// SignProtocolRequestClaims - Sign a protocol request body together with its replay protection claims
+func SignProtocolRequestClaims(protocol *models.Protocol, req interface{}, claims *models.ProtocolRequestClaims, signingFunc func([]byte) (string, error)) (string, error) {
+	digest, err := ProtocolRequestDigest(protocol.SpecsVersion, req, claims)
 	if err != nil {
 		return "", err
 	}
 
-	return signature, nil
+	return signingFunc(digest)
 }
@@ -0,0 +1,28 @@
+package evm
+
+import (
+	"github.com/anima-protocol/anima-go/models"
+)
+
+func SignSharingAuthorization(protocol *models.Protocol, content []byte, signingFunc func([]byte) (string, error)) (string, error) {
+	c, err := contentTypedData(protocol.GetSpecsVersion(), content)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := GetEIP712Message(c)
+	if err != nil {
+		return "", err
+	}
+
+	return signingFunc(digest)
+}
+
+func VerifySharingAuthorization(publicAddress string, specsVersion string, content []byte, signature string) (bool, error) {
+	c, err := contentTypedData(specsVersion, content)
+	if err != nil {
+		return false, err
+	}
+
+	return VerifySignature(publicAddress, c, signature)
+}
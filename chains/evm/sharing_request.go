@@ -0,0 +1,33 @@
+package evm
+
+import (
+	"github.com/anima-protocol/anima-go/models"
+)
+
+func SignSharingRequest(protocol *models.Protocol, content []byte, signingFunc func([]byte) (string, error)) (string, error) {
+	c, err := contentTypedData(protocol.GetSpecsVersion(), content)
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := GetEIP712Message(c)
+	if err != nil {
+		return "", err
+	}
+
+	signature, err := signingFunc(digest)
+	if err != nil {
+		return "", err
+	}
+
+	return signature, nil
+}
+
+func VerifySharingRequest(publicAddress string, specsVersion string, content []byte, signature string) (bool, error) {
+	c, err := contentTypedData(specsVersion, content)
+	if err != nil {
+		return false, err
+	}
+
+	return VerifySignature(publicAddress, c, signature)
+}
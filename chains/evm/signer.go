@@ -0,0 +1,21 @@
+package evm
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// PrivateKeySigningFunc - Signing function over a local secp256k1 private key
+func PrivateKeySigningFunc(privateKey *ecdsa.PrivateKey) func([]byte) (string, error) {
+	return func(digest []byte) (string, error) {
+		signature, err := crypto.Sign(digest, privateKey)
+		if err != nil {
+			return "", err
+		}
+
+		signature[64] += 27
+		return hex.EncodeToString(signature), nil
+	}
+}
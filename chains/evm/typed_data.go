@@ -0,0 +1,59 @@
+package evm
+
+import (
+	"encoding/json"
+
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// contentTypedData - EIP-712 typed data over the hash of JSON content serialized as under specsVersion
+func contentTypedData(specsVersion string, content []byte) ([]byte, error) {
+	contentBytes, err := crypto.Canonicalize(specsVersion, content)
+	if err != nil {
+		return nil, err
+	}
+
+	return hashTypedData(crypto.Hash(contentBytes))
+}
+
+func hashTypedData(hash string) ([]byte, error) {
+	message := make(map[string]interface{})
+	message["content"] = hash
+
+	sigRequest := apitypes.TypedData{
+		Domain: apitypes.TypedDataDomain{
+			Name:    models.PROTOCOL_NAME,
+			Version: models.PROTOCOL_VERSION,
+			ChainId: math.NewHexOrDecimal256(1),
+		},
+		PrimaryType: "Main",
+		Types: apitypes.Types{
+			"EIP712Domain": []apitypes.Type{
+				{
+					Name: "name",
+					Type: "string",
+				},
+				{
+					Name: "chainId",
+					Type: "uint256",
+				},
+				{
+					Name: "version",
+					Type: "string",
+				},
+			},
+			"Main": []apitypes.Type{
+				{
+					Name: "content",
+					Type: "string",
+				},
+			},
+		},
+		Message: message,
+	}
+
+	return json.Marshal(sigRequest)
+}
@@ -0,0 +1,40 @@
+package evm
+
+import (
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/models"
+)
+
+// SignVerification - Sign the content of a VerifyResponse as the protocol node
+func SignVerification(protocol *models.Protocol, content interface{}, signingFunc func([]byte) (string, error)) (string, error) {
+	b, err := crypto.CanonicalJSON(protocol.SpecsVersion, content)
+	if err != nil {
+		return "", err
+	}
+
+	c, err := hashTypedData(crypto.Hash(b))
+	if err != nil {
+		return "", err
+	}
+
+	digest, err := GetEIP712Message(c)
+	if err != nil {
+		return "", err
+	}
+
+	return signingFunc(digest)
+}
+
+func VerifyVerification(publicAddress string, specsVersion string, content interface{}, signature string) (bool, error) {
+	b, err := crypto.CanonicalJSON(specsVersion, content)
+	if err != nil {
+		return false, err
+	}
+
+	c, err := hashTypedData(crypto.Hash(b))
+	if err != nil {
+		return false, err
+	}
+
+	return VerifySignature(publicAddress, c, signature)
+}
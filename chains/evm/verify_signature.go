@@ -2,33 +2,49 @@ package evm
 
 import (
 	"encoding/hex"
-	"fmt"
 	"strings"
 
+	"github.com/anima-protocol/anima-go/logger"
+	"github.com/anima-protocol/anima-go/models"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
 func VerifySignature(publicAddress string, data []byte, userSignature string) (bool, error) {
+	message, err := GetEIP712Message(data)
+	if err != nil {
+		return false, err
+	}
+
+	recoveredAddr, err := RecoverAddress(message, userSignature)
+	if err != nil {
+		return false, err
+	}
+
+	if !strings.EqualFold(recoveredAddr, publicAddress) {
+		logger.Debug("signature signer mismatch", logger.String("chain", models.CHAIN_ETH), logger.String("public_address", publicAddress), logger.String("recovered_address", recoveredAddr))
+		return false, models.NewError(models.ErrBadSignature, "public address and signer address does not match")
+	}
+
+	return true, nil
+}
+
+// RecoverAddress - Address of the signer of an EIP-712 digest
+func RecoverAddress(digest []byte, userSignature string) (string, error) {
 	if len(userSignature) < 3 {
-		return false, fmt.Errorf("invalid signature length: %d", len(userSignature))
+		return "", models.NewError(models.ErrBadSignature, "invalid signature length: %d", len(userSignature))
 	}
 
 	if userSignature[0:2] == "0x" {
 		userSignature = userSignature[2:]
 	}
 
-	message, err := GetEIP712Message(data)
-	if err != nil {
-		return false, err
-	}
-
 	signature, err := hex.DecodeString(userSignature)
 	if err != nil {
-		return false, err
+		return "", models.WrapError(models.ErrBadSignature, err, "invalid signature encoding")
 	}
 
 	if len(signature) != 65 {
-		return false, fmt.Errorf("invalid signature length: %d", len(signature))
+		return "", models.NewError(models.ErrBadSignature, "invalid signature length: %d", len(signature))
 	}
 
 	if signature[64] == 27 || signature[64] == 28 {
@@ -36,24 +52,18 @@ func VerifySignature(publicAddress string, data []byte, userSignature string) (b
 	}
 
 	if signature[64] != 0 && signature[64] != 1 {
-		return false, fmt.Errorf("invalid recovery id: %d", signature[64])
+		return "", models.NewError(models.ErrBadSignature, "invalid recovery id: %d", signature[64])
 	}
 
-	pubKeyRaw, err := crypto.Ecrecover(message, signature)
+	pubKeyRaw, err := crypto.Ecrecover(digest, signature)
 	if err != nil {
-		return false, fmt.Errorf("invalid signature: %s", err.Error())
+		return "", models.WrapError(models.ErrBadSignature, err, "invalid signature")
 	}
 
 	pubKey, err := crypto.UnmarshalPubkey(pubKeyRaw)
 	if err != nil {
-		return false, err
+		return "", models.WrapError(models.ErrBadSignature, err, "invalid signature")
 	}
 
-	recoveredAddr := crypto.PubkeyToAddress(*pubKey)
-	fmt.Printf("-> recovered_addr = %v\n", recoveredAddr)
-	if !strings.EqualFold(recoveredAddr.String(), publicAddress) {
-		return false, fmt.Errorf("public address and signer address does not match")
-	}
-
-	return true, nil
+	return crypto.PubkeyToAddress(*pubKey).String(), nil
 }
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/logger"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/node"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// anima-node - Run the reference Anima Protocol node
+//
+// The node key is read from ANIMA_NODE_PRIVATE_KEY, a random one is used when unset.
+func main() {
+	addr := flag.String("addr", models.LOCALNET, "listen address")
+	dir := flag.String("dir", "", "directory persisting credentials and verifiers, in memory only when empty")
+	id := flag.String("id", "anima:protocol:local", "node identifier")
+	specsVersion := flag.String("specs-version", models.SPECS_VERSION_JCS, "specs version of signed verifications")
+	flag.Parse()
+
+	logger.SetHandler(logger.NewTextHandler(os.Stderr, logger.LEVEL_INFO))
+
+	if err := run(*addr, *dir, *id, *specsVersion); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(addr string, dir string, id string, specsVersion string) error {
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		return err
+	}
+
+	if hexKey := os.Getenv("ANIMA_NODE_PRIVATE_KEY"); hexKey != "" {
+		if privateKey, err = crypto.HexToECDSA(strings.TrimPrefix(hexKey, "0x")); err != nil {
+			return fmt.Errorf("invalid ANIMA_NODE_PRIVATE_KEY")
+		}
+	}
+
+	anima := &models.Protocol{
+		Chain:        models.CHAIN_ETH,
+		SpecsVersion: specsVersion,
+		SigningFunc:  evm.PrivateKeySigningFunc(privateKey),
+	}
+
+	identity := &protocol.AnimaProtocol{
+		Id:            id,
+		PublicAddress: crypto.PubkeyToAddress(privateKey.PublicKey).Hex(),
+		Chain:         models.CHAIN_ETH,
+	}
+
+	n, err := node.NewNode(anima, identity, dir)
+	if err != nil {
+		return err
+	}
+
+	network, stop, err := n.Start(addr)
+	if err != nil {
+		return err
+	}
+	defer stop()
+
+	fmt.Printf("anima node %s listening on %s\n", identity.PublicAddress, network)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	<-signals
+	return nil
+}
@@ -0,0 +1,322 @@
+package config
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/utils"
+	"github.com/ethereum/go-ethereum/crypto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	/* PROFILES */
+	PROFILE_MAINNET = "mainnet"
+	PROFILE_TESTNET = "testnet"
+	PROFILE_LOCAL   = "local"
+
+	/* SIGNERS */
+	SIGNER_PRIVATE_KEY      = "private_key"
+	SIGNER_PRIVATE_KEY_FILE = "private_key_file"
+	SIGNER_CUSTOM           = "custom"
+
+	DEFAULT_PROFILE = PROFILE_MAINNET
+)
+
+// Profile - Network preset selected by name
+type Profile struct {
+	Network string
+	Chain   string
+	Secure  bool
+}
+
+var Profiles = map[string]*Profile{
+	PROFILE_MAINNET: {Network: models.MAINNET, Chain: models.CHAIN_ETH, Secure: true},
+	PROFILE_TESTNET: {Network: models.TESTNET, Chain: models.CHAIN_ETH, Secure: true},
+	PROFILE_LOCAL:   {Network: models.LOCALNET, Chain: models.CHAIN_ETH, Secure: false},
+}
+
+// Duration - time.Duration read from strings such as "30s" or "24h"
+type Duration time.Duration
+
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+func (d *Duration) UnmarshalText(text []byte) error {
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return err
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+type TLSConfig struct {
+	// CAFile - PEM bundle of trusted certificate authorities, system roots when empty
+	CAFile             string `json:"ca_file,omitempty" yaml:"ca_file,omitempty"`
+	ServerName         string `json:"server_name,omitempty" yaml:"server_name,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty" yaml:"insecure_skip_verify,omitempty"`
+}
+
+type SignerConfig struct {
+	// Type - One of SIGNER_PRIVATE_KEY, SIGNER_PRIVATE_KEY_FILE or SIGNER_CUSTOM
+	Type           string `json:"type,omitempty" yaml:"type,omitempty"`
+	PrivateKey     string `json:"private_key,omitempty" yaml:"private_key,omitempty"`
+	PrivateKeyFile string `json:"private_key_file,omitempty" yaml:"private_key_file,omitempty"`
+}
+
+// Config - Settings a models.Protocol is built from
+//
+// Values are layered from lowest to highest precedence: profile, file, environment, options.
+type Config struct {
+	Profile             string       `json:"profile,omitempty" yaml:"profile,omitempty"`
+	Network             string       `json:"network,omitempty" yaml:"network,omitempty"`
+	Chain               string       `json:"chain,omitempty" yaml:"chain,omitempty"`
+	Secure              *bool        `json:"secure,omitempty" yaml:"secure,omitempty"`
+	SpecsVersion        string       `json:"specs_version,omitempty" yaml:"specs_version,omitempty"`
+	EncryptAttributes   bool         `json:"encrypt_attributes,omitempty" yaml:"encrypt_attributes,omitempty"`
+	AuthorizationMaxAge Duration     `json:"authorization_max_age,omitempty" yaml:"authorization_max_age,omitempty"`
+	Timeout             Duration     `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	TLS                 TLSConfig    `json:"tls,omitempty" yaml:"tls,omitempty"`
+	Signer              SignerConfig `json:"signer,omitempty" yaml:"signer,omitempty"`
+
+	signingFunc func([]byte) (string, error)
+	retry       *models.RetryPolicy
+}
+
+// Load - Build a models.Protocol from an optional file, the environment and options
+//
+// When path is empty, ANIMA_CONFIG is used as the file path if set.
+func Load(path string, opts ...Option) (*models.Protocol, error) {
+	config := &Config{}
+
+	if path == "" {
+		path = lookupEnv(ENV_CONFIG)
+	}
+
+	if path != "" {
+		if err := config.LoadFile(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.LoadEnv(); err != nil {
+		return nil, err
+	}
+
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	return config.Protocol()
+}
+
+// LoadFile - Read settings from a YAML or JSON file, unknown keys are rejected
+func (c *Config) LoadFile(path string) error {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return models.WrapError(models.ErrInvalidConfig, err, "could not read config file")
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(c); err != nil {
+			return models.WrapError(models.ErrInvalidConfig, err, "invalid config file %s", path)
+		}
+	case ".json":
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(c); err != nil {
+			return models.WrapError(models.ErrInvalidConfig, err, "invalid config file %s", path)
+		}
+	default:
+		return models.NewError(models.ErrInvalidConfig, "unsupported config file extension %q", filepath.Ext(path))
+	}
+
+	return nil
+}
+
+// Protocol - Resolve the profile, validate settings and build the models.Protocol
+func (c *Config) Protocol() (*models.Protocol, error) {
+	profileName := c.Profile
+	if profileName == "" && c.Network == "" {
+		profileName = DEFAULT_PROFILE
+	}
+
+	resolved := *c
+	if profileName != "" {
+		profile, ok := Profiles[profileName]
+		if !ok {
+			return nil, models.NewError(models.ErrInvalidConfig, "unknown profile %q", profileName)
+		}
+
+		if resolved.Network == "" {
+			resolved.Network = profile.Network
+		}
+		if resolved.Chain == "" {
+			resolved.Chain = profile.Chain
+		}
+		if resolved.Secure == nil {
+			secure := profile.Secure
+			resolved.Secure = &secure
+		}
+	}
+
+	// Plaintext is only used when asked for explicitly or by the local profile
+	if resolved.Secure == nil {
+		secure := true
+		resolved.Secure = &secure
+	}
+
+	if err := resolved.validate(); err != nil {
+		return nil, err
+	}
+
+	signingFunc, err := resolved.signer()
+	if err != nil {
+		return nil, err
+	}
+
+	protocol := &models.Protocol{
+		Network:             resolved.Network,
+		Chain:               resolved.Chain,
+		SigningFunc:         signingFunc,
+		Secure:              *resolved.Secure,
+		SpecsVersion:        resolved.SpecsVersion,
+		EncryptAttributes:   resolved.EncryptAttributes,
+		AuthorizationMaxAge: time.Duration(resolved.AuthorizationMaxAge),
+		Timeout:             time.Duration(resolved.Timeout),
+		Retry:               resolved.retry,
+	}
+
+	if protocol.Secure {
+		protocol.TLS, err = resolved.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return protocol, nil
+}
+
+func (c *Config) validate() error {
+	if c.Network == "" {
+		return models.NewError(models.ErrInvalidConfig, "network is required")
+	}
+
+	if !utils.InArray(c.Chain, models.AVAILABLE_CHAIN) {
+		return models.NewError(models.ErrInvalidConfig, "unsupported chain %q", c.Chain)
+	}
+
+	if c.SpecsVersion != "" && c.SpecsVersion != models.SPECS_VERSION_LEGACY && c.SpecsVersion != models.SPECS_VERSION_JCS {
+		return models.NewError(models.ErrInvalidConfig, "unsupported specs version %q", c.SpecsVersion)
+	}
+
+	if c.AuthorizationMaxAge < 0 {
+		return models.NewError(models.ErrInvalidConfig, "authorization_max_age must not be negative")
+	}
+
+	if c.retry != nil && c.retry.MaxAttempts < 1 {
+		return models.NewError(models.ErrInvalidConfig, "retry max attempts must be at least 1")
+	}
+
+	if c.Timeout < 0 {
+		return models.NewError(models.ErrInvalidConfig, "timeout must not be negative")
+	}
+
+	if (c.Secure == nil || !*c.Secure) && (c.TLS != TLSConfig{}) {
+		return models.NewError(models.ErrInvalidConfig, "tls settings require a secure connection")
+	}
+
+	return nil
+}
+
+// signer - Signing function selected by Signer.Type, inferred from the settings when empty
+//
+// Every Anima Protocol request is signed, so a configuration without signer is rejected.
+func (c *Config) signer() (func([]byte) (string, error), error) {
+	signerType := c.Signer.Type
+	if signerType == "" {
+		switch {
+		case c.signingFunc != nil:
+			signerType = SIGNER_CUSTOM
+		case c.Signer.PrivateKey != "":
+			signerType = SIGNER_PRIVATE_KEY
+		case c.Signer.PrivateKeyFile != "":
+			signerType = SIGNER_PRIVATE_KEY_FILE
+		default:
+			return nil, models.NewError(models.ErrInvalidConfig, "a signer is required")
+		}
+	}
+
+	switch signerType {
+	case SIGNER_CUSTOM:
+		if c.signingFunc == nil {
+			return nil, models.NewError(models.ErrInvalidConfig, "custom signer requires WithSigningFunc")
+		}
+		return c.signingFunc, nil
+	case SIGNER_PRIVATE_KEY:
+		return privateKeySigner(c.Signer.PrivateKey)
+	case SIGNER_PRIVATE_KEY_FILE:
+		if c.Signer.PrivateKeyFile == "" {
+			return nil, models.NewError(models.ErrInvalidConfig, "signer private_key_file is required")
+		}
+
+		content, err := ioutil.ReadFile(c.Signer.PrivateKeyFile)
+		if err != nil {
+			return nil, models.WrapError(models.ErrInvalidConfig, err, "could not read signer private key file")
+		}
+		return privateKeySigner(string(content))
+	}
+
+	return nil, models.NewError(models.ErrInvalidConfig, "unknown signer %q", signerType)
+}
+
+func privateKeySigner(hexKey string) (func([]byte) (string, error), error) {
+	hexKey = strings.TrimPrefix(strings.TrimSpace(hexKey), "0x")
+	if hexKey == "" {
+		return nil, models.NewError(models.ErrInvalidConfig, "signer private_key is required")
+	}
+
+	privateKey, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		// The key itself is never part of the error
+		return nil, models.NewError(models.ErrInvalidConfig, "invalid signer private key")
+	}
+
+	return evm.PrivateKeySigningFunc(privateKey), nil
+}
+
+func (c *Config) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName:         c.TLS.ServerName,
+		InsecureSkipVerify: c.TLS.InsecureSkipVerify,
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if c.TLS.CAFile != "" {
+		pem, err := ioutil.ReadFile(c.TLS.CAFile)
+		if err != nil {
+			return nil, models.WrapError(models.ErrInvalidConfig, err, "could not read tls ca_file")
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, models.NewError(models.ErrInvalidConfig, "no certificate found in tls ca_file %s", c.TLS.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,201 @@
+package config
+
+import (
+	"crypto/tls"
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+const PRIVATE_KEY = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+
+// clearEnv - Unset the ANIMA_* variables for the test, empty values are ignored by LoadEnv
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, name := range []string{
+		ENV_CONFIG, ENV_PROFILE, ENV_NETWORK, ENV_CHAIN, ENV_SECURE, ENV_SPECS_VERSION,
+		ENV_ENCRYPT_ATTRIBUTES, ENV_AUTHORIZATION_MAX_AGE, ENV_TIMEOUT, ENV_TLS_CA_FILE,
+		ENV_TLS_SERVER_NAME, ENV_TLS_INSECURE_SKIP_VERIFY, ENV_SIGNER, ENV_SIGNER_PRIVATE_KEY,
+		ENV_SIGNER_PRIVATE_KEY_FILE,
+	} {
+		t.Setenv(name, "")
+	}
+}
+
+func writeFile(t *testing.T, name string, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := ioutil.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadLayers(t *testing.T) {
+	clearEnv(t)
+	path := writeFile(t, "anima.yaml", `
+profile: testnet
+specs_version: "1.0.0"
+timeout: 10s
+authorization_max_age: 1h
+signer:
+  private_key: "0x`+PRIVATE_KEY+`"
+`)
+
+	t.Setenv(ENV_TIMEOUT, "20s")
+	t.Setenv(ENV_SPECS_VERSION, models.SPECS_VERSION_JCS)
+
+	anima, err := Load(path, WithSpecsVersion(models.SPECS_VERSION_LEGACY), WithEncryptAttributes(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// File
+	if anima.Network != models.TESTNET || anima.AuthorizationMaxAge != time.Hour || anima.SigningFunc == nil {
+		t.Errorf("file settings not applied: %+v", anima)
+	}
+
+	// Environment over file
+	if anima.Timeout != 20*time.Second {
+		t.Errorf("timeout = %s, want the environment value", anima.Timeout)
+	}
+
+	// Options over environment
+	if anima.SpecsVersion != models.SPECS_VERSION_LEGACY || !anima.EncryptAttributes {
+		t.Errorf("options not applied: specs version %s, encrypt %t", anima.SpecsVersion, anima.EncryptAttributes)
+	}
+}
+
+func TestLoadConfigFileFromEnv(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(ENV_CONFIG, writeFile(t, "anima.json", `{"profile": "local", "signer": {"private_key": "`+PRIVATE_KEY+`"}}`))
+
+	anima, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if anima.Network != models.LOCALNET {
+		t.Errorf("network = %s, want %s", anima.Network, models.LOCALNET)
+	}
+}
+
+func TestLoadProfiles(t *testing.T) {
+	clearEnv(t)
+	for _, test := range []struct {
+		profile string
+		network string
+		secure  bool
+	}{
+		{"", models.MAINNET, true},
+		{PROFILE_MAINNET, models.MAINNET, true},
+		{PROFILE_TESTNET, models.TESTNET, true},
+		{PROFILE_LOCAL, models.LOCALNET, false},
+	} {
+		t.Run(test.profile, func(t *testing.T) {
+			anima, err := Load("", WithProfile(test.profile), WithPrivateKey(PRIVATE_KEY))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if anima.Network != test.network || anima.Chain != models.CHAIN_ETH || anima.Secure != test.secure || (anima.TLS != nil) != test.secure {
+				t.Errorf("protocol = %+v", anima)
+			}
+		})
+	}
+
+	if _, err := Load("", WithProfile("staging"), WithPrivateKey(PRIVATE_KEY)); !errors.Is(err, models.ErrInvalidConfig) {
+		t.Errorf("err = %v, want %v", err, models.ErrInvalidConfig)
+	}
+}
+
+func TestLoadExplicitNetworkIsSecure(t *testing.T) {
+	clearEnv(t)
+	anima, err := Load("", WithNetwork("anima.internal:443"), WithChain(models.CHAIN_ETH), WithPrivateKey(PRIVATE_KEY))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !anima.Secure || anima.TLS == nil || anima.TLS.MinVersion != tls.VersionTLS12 || anima.TLS.RootCAs != nil || anima.TLS.InsecureSkipVerify {
+		t.Errorf("tls = %+v, want system roots and TLS 1.2", anima.TLS)
+	}
+}
+
+func TestLoadFileRejectsUnknownKeys(t *testing.T) {
+	clearEnv(t)
+	for name, content := range map[string]string{
+		"anima.yaml": "profile: testnet\nnetwork_url: anima.internal:443\n",
+		"anima.yml":  "signer:\n  private_key: \"" + PRIVATE_KEY + "\"\n  passphrase: secret\n",
+		"anima.json": `{"profile": "testnet", "networkUrl": "anima.internal:443"}`,
+		"anima.toml": `profile = "testnet"`,
+	} {
+		if _, err := Load(writeFile(t, name, content)); !errors.Is(err, models.ErrInvalidConfig) {
+			t.Errorf("%s: err = %v, want %v", name, err, models.ErrInvalidConfig)
+		}
+	}
+}
+
+func TestLoadRejectsInvalidSettings(t *testing.T) {
+	clearEnv(t)
+	for name, opts := range map[string][]Option{
+		"no signer":             {WithProfile(PROFILE_TESTNET)},
+		"custom signer":         {WithSigningFunc(nil)},
+		"private key":           {WithPrivateKey("0xnot-a-key")},
+		"chain":                 {WithChain("SOL"), WithPrivateKey(PRIVATE_KEY)},
+		"specs version":         {WithSpecsVersion("2.0.0"), WithPrivateKey(PRIVATE_KEY)},
+		"authorization max age": {WithAuthorizationMaxAge(-time.Second), WithPrivateKey(PRIVATE_KEY)},
+		"timeout":               {WithTimeout(-time.Second), WithPrivateKey(PRIVATE_KEY)},
+		"retry policy":          {WithRetryPolicy(&models.RetryPolicy{}), WithPrivateKey(PRIVATE_KEY)},
+		"tls without secure":    {WithProfile(PROFILE_LOCAL), WithTLS(TLSConfig{ServerName: "anima"}), WithPrivateKey(PRIVATE_KEY)},
+		"tls missing ca file":   {WithTLS(TLSConfig{CAFile: "/nonexistent/ca.pem"}), WithPrivateKey(PRIVATE_KEY)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			anima, err := Load("", opts...)
+			if anima != nil || !errors.Is(err, models.ErrInvalidConfig) {
+				t.Errorf("protocol = %+v, err = %v, want %v", anima, err, models.ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestLoadRejectsInvalidEnvironment(t *testing.T) {
+	for name, value := range map[string]string{
+		ENV_SECURE:             "maybe",
+		ENV_TIMEOUT:            "30",
+		ENV_ENCRYPT_ATTRIBUTES: "yes please",
+	} {
+		t.Run(name, func(t *testing.T) {
+			clearEnv(t)
+			t.Setenv(name, value)
+
+			anima, err := Load("", WithPrivateKey(PRIVATE_KEY))
+			if anima != nil || !errors.Is(err, models.ErrInvalidConfig) {
+				t.Errorf("protocol = %+v, err = %v, want %v", anima, err, models.ErrInvalidConfig)
+			}
+		})
+	}
+}
+
+func TestLoadSignerFromFile(t *testing.T) {
+	clearEnv(t)
+	t.Setenv(ENV_SIGNER_PRIVATE_KEY_FILE, writeFile(t, "signer.key", PRIVATE_KEY+"\n"))
+
+	anima, err := Load("")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := anima.SigningFunc(make([]byte, 32)); err != nil {
+		t.Errorf("signing with the key file: %v", err)
+	}
+
+	t.Setenv(ENV_SIGNER_PRIVATE_KEY_FILE, "")
+	t.Setenv(ENV_SIGNER, SIGNER_PRIVATE_KEY_FILE)
+	if _, err := Load(""); !errors.Is(err, models.ErrInvalidConfig) {
+		t.Errorf("err = %v, want %v without a key file", err, models.ErrInvalidConfig)
+	}
+}
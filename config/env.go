@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+const (
+	/* ENVIRONMENT */
+	ENV_CONFIG                   = "ANIMA_CONFIG"
+	ENV_PROFILE                  = "ANIMA_PROFILE"
+	ENV_NETWORK                  = "ANIMA_NETWORK"
+	ENV_CHAIN                    = "ANIMA_CHAIN"
+	ENV_SECURE                   = "ANIMA_SECURE"
+	ENV_SPECS_VERSION            = "ANIMA_SPECS_VERSION"
+	ENV_ENCRYPT_ATTRIBUTES       = "ANIMA_ENCRYPT_ATTRIBUTES"
+	ENV_AUTHORIZATION_MAX_AGE    = "ANIMA_AUTHORIZATION_MAX_AGE"
+	ENV_TIMEOUT                  = "ANIMA_TIMEOUT"
+	ENV_TLS_CA_FILE              = "ANIMA_TLS_CA_FILE"
+	ENV_TLS_SERVER_NAME          = "ANIMA_TLS_SERVER_NAME"
+	ENV_TLS_INSECURE_SKIP_VERIFY = "ANIMA_TLS_INSECURE_SKIP_VERIFY"
+	ENV_SIGNER                   = "ANIMA_SIGNER"
+	ENV_SIGNER_PRIVATE_KEY       = "ANIMA_SIGNER_PRIVATE_KEY"
+	ENV_SIGNER_PRIVATE_KEY_FILE  = "ANIMA_SIGNER_PRIVATE_KEY_FILE"
+)
+
+// LoadEnv - Override settings with the ANIMA_* environment variables that are set
+func (c *Config) LoadEnv() error {
+	setString(ENV_PROFILE, &c.Profile)
+	setString(ENV_NETWORK, &c.Network)
+	setString(ENV_CHAIN, &c.Chain)
+	setString(ENV_SPECS_VERSION, &c.SpecsVersion)
+	setString(ENV_TLS_CA_FILE, &c.TLS.CAFile)
+	setString(ENV_TLS_SERVER_NAME, &c.TLS.ServerName)
+	setString(ENV_SIGNER, &c.Signer.Type)
+	setString(ENV_SIGNER_PRIVATE_KEY, &c.Signer.PrivateKey)
+	setString(ENV_SIGNER_PRIVATE_KEY_FILE, &c.Signer.PrivateKeyFile)
+
+	if value := lookupEnv(ENV_SECURE); value != "" {
+		secure, err := strconv.ParseBool(value)
+		if err != nil {
+			return models.WrapError(models.ErrInvalidConfig, err, "invalid %s", ENV_SECURE)
+		}
+		c.Secure = &secure
+	}
+
+	if err := setBool(ENV_ENCRYPT_ATTRIBUTES, &c.EncryptAttributes); err != nil {
+		return err
+	}
+
+	if err := setBool(ENV_TLS_INSECURE_SKIP_VERIFY, &c.TLS.InsecureSkipVerify); err != nil {
+		return err
+	}
+
+	if err := setDuration(ENV_AUTHORIZATION_MAX_AGE, &c.AuthorizationMaxAge); err != nil {
+		return err
+	}
+
+	return setDuration(ENV_TIMEOUT, &c.Timeout)
+}
+
+func lookupEnv(name string) string {
+	value, _ := os.LookupEnv(name)
+	return value
+}
+
+func setString(name string, target *string) {
+	if value := lookupEnv(name); value != "" {
+		*target = value
+	}
+}
+
+func setBool(name string, target *bool) error {
+	value := lookupEnv(name)
+	if value == "" {
+		return nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return models.WrapError(models.ErrInvalidConfig, err, "invalid %s", name)
+	}
+	*target = parsed
+	return nil
+}
+
+func setDuration(name string, target *Duration) error {
+	value := lookupEnv(name)
+	if value == "" {
+		return nil
+	}
+
+	if err := target.UnmarshalText([]byte(value)); err != nil {
+		return models.WrapError(models.ErrInvalidConfig, err, "invalid %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package config
+
+import (
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+// Option - Functional override applied after file and environment settings
+type Option func(*Config)
+
+func WithProfile(profile string) Option {
+	return func(c *Config) {
+		c.Profile = profile
+	}
+}
+
+func WithNetwork(network string) Option {
+	return func(c *Config) {
+		c.Network = network
+	}
+}
+
+func WithChain(chain string) Option {
+	return func(c *Config) {
+		c.Chain = chain
+	}
+}
+
+func WithSecure(secure bool) Option {
+	return func(c *Config) {
+		c.Secure = &secure
+	}
+}
+
+func WithSpecsVersion(specsVersion string) Option {
+	return func(c *Config) {
+		c.SpecsVersion = specsVersion
+	}
+}
+
+func WithEncryptAttributes(encrypt bool) Option {
+	return func(c *Config) {
+		c.EncryptAttributes = encrypt
+	}
+}
+
+func WithAuthorizationMaxAge(maxAge time.Duration) Option {
+	return func(c *Config) {
+		c.AuthorizationMaxAge = Duration(maxAge)
+	}
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Config) {
+		c.Timeout = Duration(timeout)
+	}
+}
+
+func WithTLS(tls TLSConfig) Option {
+	return func(c *Config) {
+		c.TLS = tls
+	}
+}
+
+// WithPrivateKey - Sign with a hex encoded secp256k1 private key
+func WithPrivateKey(privateKey string) Option {
+	return func(c *Config) {
+		c.Signer = SignerConfig{Type: SIGNER_PRIVATE_KEY, PrivateKey: privateKey}
+	}
+}
+
+// WithSigningFunc - Sign with a custom function, e.g. backed by a KMS or HSM
+func WithSigningFunc(signingFunc func([]byte) (string, error)) Option {
+	return func(c *Config) {
+		c.Signer = SignerConfig{Type: SIGNER_CUSTOM}
+		c.signingFunc = signingFunc
+	}
+}
+
+// WithRetryPolicy - Retry transient Anima Protocol errors with policy, nil restores the default
+func WithRetryPolicy(policy *models.RetryPolicy) Option {
+	return func(c *Config) {
+		c.retry = policy
+	}
+}
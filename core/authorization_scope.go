@@ -0,0 +1,86 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+// ScopeError - Issuing authorization constraint violated by an IssueRequest
+type ScopeError struct {
+	Constraint string
+	Message    string
+}
+
+func (e *ScopeError) Error() string {
+	return fmt.Sprintf("issuing authorization %s: %s", e.Constraint, e.Message)
+}
+
+func (e *ScopeError) Is(target error) bool {
+	if e.Constraint == "requested_at" && target == models.ErrAuthorizationExpired {
+		return true
+	}
+	return target == models.ErrInvalidRequest
+}
+
+// CheckIssuingAuthorizationScope - Ensure the request stays within what the owner authorized
+func CheckIssuingAuthorizationScope(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, issuingAuthorization *models.IssuingAuthorization, now time.Time) error {
+	if issuer == nil || issuer.Id == "" {
+		return models.NewError(models.ErrInvalidRequest, "issuer is required")
+	}
+
+	if request == nil || request.Document == nil || issuingAuthorization == nil {
+		return models.NewError(models.ErrInvalidRequest, "issue request and its issuing authorization are required")
+	}
+
+	authorizedIssuer := issuingAuthorization.Issuer
+	if authorizedIssuer.ID != issuer.Id || !strings.EqualFold(authorizedIssuer.PublicAddress, issuer.PublicAddress) || authorizedIssuer.Chain != issuer.Chain {
+		return &ScopeError{
+			Constraint: "issuer",
+			Message:    fmt.Sprintf("authorized issuer %s (%s) does not match configured issuer %s (%s)", authorizedIssuer.ID, authorizedIssuer.PublicAddress, issuer.Id, issuer.PublicAddress),
+		}
+	}
+
+	if issuingAuthorization.Specs != request.Document.Specs {
+		return &ScopeError{
+			Constraint: "specs",
+			Message:    fmt.Sprintf("authorized document specs %q does not match %q", issuingAuthorization.Specs, request.Document.Specs),
+		}
+	}
+
+	names := make([]string, 0, len(request.Attributes))
+	for name := range request.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !issuingAuthorization.Attributes[name] {
+			return &ScopeError{
+				Constraint: "attributes",
+				Message:    fmt.Sprintf("attribute %q is not authorized", name),
+			}
+		}
+	}
+
+	requestedAt := time.Unix(int64(issuingAuthorization.RequestedAt), 0)
+	if requestedAt.After(now.Add(models.AUTHORIZATION_CLOCK_SKEW)) {
+		return &ScopeError{
+			Constraint: "requested_at",
+			Message:    fmt.Sprintf("requested at %s is in the future", requestedAt.UTC().Format(time.RFC3339)),
+		}
+	}
+
+	if maxAge := anima.GetAuthorizationMaxAge(); now.Sub(requestedAt) > maxAge {
+		return &ScopeError{
+			Constraint: "requested_at",
+			Message:    fmt.Sprintf("requested at %s is older than %s", requestedAt.UTC().Format(time.RFC3339), maxAge),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,153 @@
+package core_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+const ISSUER_ADDRESS = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+// scopeFixture - Issuer, request and the authorization that exactly covers them
+func scopeFixture() (*protocol.AnimaIssuer, *protocol.IssueRequest, *models.IssuingAuthorization) {
+	issuer := &protocol.AnimaIssuer{Id: "issuer", PublicAddress: ISSUER_ADDRESS, Chain: models.CHAIN_ETH}
+	request := &protocol.IssueRequest{
+		Document:   &protocol.IssDocument{Specs: models.DOCUMENT_SPECS_PASSPORT},
+		Attributes: map[string]*protocol.IssAttribute{"firstname": {}, "lastname": {}},
+	}
+	authorization := &models.IssuingAuthorization{
+		Specs:       models.DOCUMENT_SPECS_PASSPORT,
+		RequestedAt: uint64(fixtureTime.Unix()),
+		Attributes:  map[string]bool{"firstname": true, "lastname": true},
+		Issuer:      models.AnimaIssuer{ID: "issuer", PublicAddress: strings.ToLower(ISSUER_ADDRESS), Chain: models.CHAIN_ETH},
+	}
+	return issuer, request, authorization
+}
+
+func TestCheckIssuingAuthorizationScope(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		change     func(issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, authorization *models.IssuingAuthorization)
+		constraint string
+	}{
+		{"in scope", func(*protocol.AnimaIssuer, *protocol.IssueRequest, *models.IssuingAuthorization) {}, ""},
+		{"attribute subset", func(_ *protocol.AnimaIssuer, request *protocol.IssueRequest, _ *models.IssuingAuthorization) {
+			delete(request.Attributes, "lastname")
+		}, ""},
+		{"issuer id", func(issuer *protocol.AnimaIssuer, _ *protocol.IssueRequest, _ *models.IssuingAuthorization) {
+			issuer.Id = "other"
+		}, "issuer"},
+		{"issuer address", func(issuer *protocol.AnimaIssuer, _ *protocol.IssueRequest, _ *models.IssuingAuthorization) {
+			issuer.PublicAddress = "0x70997970C51812dc3A010C7d01b50e0d17dc79C8"
+		}, "issuer"},
+		{"issuer chain", func(issuer *protocol.AnimaIssuer, _ *protocol.IssueRequest, _ *models.IssuingAuthorization) {
+			issuer.Chain = "SOL"
+		}, "issuer"},
+		{"specs", func(_ *protocol.AnimaIssuer, request *protocol.IssueRequest, _ *models.IssuingAuthorization) {
+			request.Document.Specs = models.DOCUMENT_SPECS_NATIONAL_ID
+		}, "specs"},
+		{"unauthorized attribute", func(_ *protocol.AnimaIssuer, request *protocol.IssueRequest, _ *models.IssuingAuthorization) {
+			request.Attributes["nationality"] = &protocol.IssAttribute{}
+		}, "attributes"},
+		{"attribute authorized as false", func(_ *protocol.AnimaIssuer, _ *protocol.IssueRequest, authorization *models.IssuingAuthorization) {
+			authorization.Attributes["lastname"] = false
+		}, "attributes"},
+		{"requested in the future", func(_ *protocol.AnimaIssuer, _ *protocol.IssueRequest, authorization *models.IssuingAuthorization) {
+			authorization.RequestedAt = uint64(fixtureTime.Add(time.Hour).Unix())
+		}, "requested_at"},
+		{"requested too long ago", func(_ *protocol.AnimaIssuer, _ *protocol.IssueRequest, authorization *models.IssuingAuthorization) {
+			authorization.RequestedAt = uint64(fixtureTime.Add(-48 * time.Hour).Unix())
+		}, "requested_at"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			issuer, request, authorization := scopeFixture()
+			test.change(issuer, request, authorization)
+
+			err := core.CheckIssuingAuthorizationScope(&models.Protocol{}, issuer, request, authorization, fixtureTime)
+			if test.constraint == "" {
+				if err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			var scopeErr *core.ScopeError
+			if !errors.As(err, &scopeErr) {
+				t.Fatalf("err = %v, want ScopeError", err)
+			}
+
+			if scopeErr.Constraint != test.constraint {
+				t.Errorf("constraint = %s, want %s", scopeErr.Constraint, test.constraint)
+			}
+
+			if !errors.Is(err, models.ErrInvalidRequest) {
+				t.Errorf("err = %v, want %v", err, models.ErrInvalidRequest)
+			}
+
+			if expired := errors.Is(err, models.ErrAuthorizationExpired); expired != (test.constraint == "requested_at") {
+				t.Errorf("errors.Is(%v, ErrAuthorizationExpired) = %t", err, expired)
+			}
+		})
+	}
+}
+
+func TestCheckIssuingAuthorizationScopeFreshnessBoundary(t *testing.T) {
+	maxAge := time.Hour
+	for _, test := range []struct {
+		name        string
+		requestedAt time.Time
+		fresh       bool
+	}{
+		{"max age", fixtureTime.Add(-maxAge), true},
+		{"past max age", fixtureTime.Add(-maxAge - time.Second), false},
+		{"clock skew", fixtureTime.Add(models.AUTHORIZATION_CLOCK_SKEW), true},
+		{"past clock skew", fixtureTime.Add(models.AUTHORIZATION_CLOCK_SKEW + time.Second), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			issuer, request, authorization := scopeFixture()
+			authorization.RequestedAt = uint64(test.requestedAt.Unix())
+
+			err := core.CheckIssuingAuthorizationScope(&models.Protocol{AuthorizationMaxAge: maxAge}, issuer, request, authorization, fixtureTime)
+			if fresh := err == nil; fresh != test.fresh {
+				t.Errorf("fresh = %t, want %t (err = %v)", fresh, test.fresh, err)
+			}
+
+			if err != nil && !errors.Is(err, models.ErrAuthorizationExpired) {
+				t.Errorf("err = %v, want %v", err, models.ErrAuthorizationExpired)
+			}
+		})
+	}
+}
+
+func TestCheckIssuingAuthorizationScopeDefaultMaxAge(t *testing.T) {
+	issuer, request, authorization := scopeFixture()
+	authorization.RequestedAt = uint64(fixtureTime.Add(-models.DEFAULT_AUTHORIZATION_MAX_AGE).Unix())
+	if err := core.CheckIssuingAuthorizationScope(&models.Protocol{}, issuer, request, authorization, fixtureTime); err != nil {
+		t.Errorf("authorization at default max age rejected: %v", err)
+	}
+
+	authorization.RequestedAt--
+	if err := core.CheckIssuingAuthorizationScope(&models.Protocol{}, issuer, request, authorization, fixtureTime); !errors.Is(err, models.ErrAuthorizationExpired) {
+		t.Errorf("err = %v, want %v", err, models.ErrAuthorizationExpired)
+	}
+}
+
+func TestCheckIssuingAuthorizationScopeRequiresIssuer(t *testing.T) {
+	_, request, authorization := scopeFixture()
+	for _, issuer := range []*protocol.AnimaIssuer{nil, {}} {
+		err := core.CheckIssuingAuthorizationScope(&models.Protocol{}, issuer, request, authorization, fixtureTime)
+		if !errors.Is(err, models.ErrInvalidRequest) {
+			t.Errorf("err = %v, want %v", err, models.ErrInvalidRequest)
+		}
+
+		var scopeErr *core.ScopeError
+		if errors.As(err, &scopeErr) {
+			t.Errorf("missing issuer reported as scope constraint %s", scopeErr.Constraint)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/anima-protocol/anima-go/crypto/encryption"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+// encryptIssuing - Replace attribute values and proof content with ciphertext for the owner
+func encryptIssuing(request *protocol.IssueRequest, publicKey string) error {
+	if publicKey == "" {
+		return fmt.Errorf("owner has no public encryption key")
+	}
+
+	for name, attr := range request.Attributes {
+		// File attributes issued from a digest carry no content
+		if len(attr.Value) > 0 {
+			value, err := encryptValue(publicKey, attr.Value)
+			if err != nil {
+				return err
+			}
+			attr.Value = value
+		}
+
+		content := request.Document.Attributes[name].Content
+		if content.Type == models.ATTRIBUTE_TYPE_FILE {
+			continue
+		}
+
+		contentValue, err := encryptValue(publicKey, []byte(content.Value))
+		if err != nil {
+			return err
+		}
+		content.Value = string(contentValue)
+		attr.Content.Value = string(contentValue)
+	}
+
+	proofContent, err := base64.StdEncoding.DecodeString(request.Proof.Content)
+	if err != nil {
+		return err
+	}
+
+	encryptedProof, err := encryptValue(publicKey, proofContent)
+	if err != nil {
+		return err
+	}
+	request.Proof.Content = base64.StdEncoding.EncodeToString(encryptedProof)
+
+	return nil
+}
+
+// DecryptIssuing - Restore attribute values and proof content encrypted to the owner
+func DecryptIssuing(request *protocol.IssueRequest, privateKey []byte) error {
+	for name, attr := range request.Attributes {
+		// File attributes issued from a digest carry no content
+		if len(attr.Value) > 0 {
+			value, err := decryptValue(privateKey, attr.Value)
+			if err != nil {
+				return fmt.Errorf("attribute %s: %v", name, err)
+			}
+			attr.Value = value
+		}
+
+		document, ok := request.Document.Attributes[name]
+		if !ok || document.Content == nil || document.Content.Type == models.ATTRIBUTE_TYPE_FILE {
+			continue
+		}
+
+		contentValue, err := decryptValue(privateKey, []byte(document.Content.Value))
+		if err != nil {
+			return fmt.Errorf("attribute %s: %v", name, err)
+		}
+		document.Content.Value = string(contentValue)
+		if attr.Content != nil {
+			attr.Content.Value = string(contentValue)
+		}
+	}
+
+	proofContent, err := base64.StdEncoding.DecodeString(request.Proof.Content)
+	if err != nil {
+		return err
+	}
+
+	decryptedProof, err := decryptValue(privateKey, proofContent)
+	if err != nil {
+		return fmt.Errorf("proof: %v", err)
+	}
+	request.Proof.Content = base64.StdEncoding.EncodeToString(decryptedProof)
+
+	return nil
+}
+
+func encryptValue(publicKey string, value []byte) ([]byte, error) {
+	encrypted, err := encryption.Encrypt(publicKey, value)
+	if err != nil {
+		return nil, err
+	}
+	return encrypted.Marshal()
+}
+
+func decryptValue(privateKey []byte, value []byte) ([]byte, error) {
+	encrypted, err := encryption.Unmarshal(value)
+	if err != nil {
+		return nil, err
+	}
+	return encryption.Decrypt(privateKey, encrypted)
+}
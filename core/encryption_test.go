@@ -0,0 +1,97 @@
+package core_test
+
+import (
+	"encoding/hex"
+	"testing"
+	"time"
+
+	anima "github.com/anima-protocol/anima-go"
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/crypto/encryption"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEncryptedIssuingRoundTrip(t *testing.T) {
+	ownerKey, err := crypto.HexToECDSA(OWNER_KEY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerPrivateKey := crypto.FromECDSA(ownerKey)
+
+	publicKeyEncryption, err := encryption.PublicKeyX25519(ownerPrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer, signingFunc := fixtureIssuer(t)
+	authorization, err := core.CreateIssuingAuthorization(&models.IssuingAuthorization{
+		Specs:       "anima:specs:document/membership@1.0.0",
+		RequestedAt: uint64(fixtureTime.Unix()),
+		Fields:      map[string]string{},
+		Attributes:  map[string]bool{"firstname": true, "photo": true},
+		Owner: models.AnimaOwner{
+			ID:                  "owner",
+			PublicAddress:       crypto.PubkeyToAddress(ownerKey.PublicKey).Hex(),
+			Chain:               models.CHAIN_ETH,
+			PublicKeyEncryption: publicKeyEncryption,
+		},
+		Issuer: models.AnimaIssuer{ID: issuer.Id, PublicAddress: issuer.PublicAddress, Chain: issuer.Chain},
+	}, evm.PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &core.IssuingOptions{Clock: fixtureClock}
+	request, err := anima.NewIssuance().
+		Document("anima:specs:document/membership@1.0.0").
+		ExpiresAt(fixtureTime.AddDate(1, 0, 0)).
+		Owner(authorization).
+		Attribute("firstname", "Jane").
+		FileDigest("photo", &core.FileDigest{Hash: hex.EncodeToString(make([]byte, 32)), MimeType: "image/png"}).
+		Proof(models.PROOF_SPECS_MANUAL_REVIEW, map[string]interface{}{"reviewer": "reviewer", "reviewed_at": fixtureTime.Add(-time.Hour).Unix(), "decision": "approved"}).
+		Options(opts).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: models.SPECS_VERSION_JCS, EncryptAttributes: true}
+	result, err := core.SignIssuingWithOptions(config, issuer, request, signingFunc, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encrypted := result.Request
+	if string(encrypted.Attributes["firstname"].Value) == "Jane" || encrypted.Document.Attributes["firstname"].Content.Value == "Jane" {
+		t.Fatal("firstname is not encrypted")
+	}
+
+	if encrypted.Proof.Content == request.Proof.Content {
+		t.Fatal("proof is not encrypted")
+	}
+
+	decrypted := proto.Clone(encrypted).(*protocol.IssueRequest)
+	if err := core.DecryptIssuing(decrypted, ownerPrivateKey); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := string(decrypted.Attributes["firstname"].Value); value != "Jane" {
+		t.Errorf("firstname value = %q", value)
+	}
+
+	if value := decrypted.Document.Attributes["firstname"].Content.Value; value != "Jane" {
+		t.Errorf("firstname content = %q", value)
+	}
+
+	if len(decrypted.Attributes["photo"].Value) != 0 || decrypted.Document.Attributes["photo"].Content.Value != encrypted.Document.Attributes["photo"].Content.Value {
+		t.Error("photo digest changed by decryption")
+	}
+
+	if decrypted.Proof.Content != request.Proof.Content {
+		t.Errorf("proof content = %s, want %s", decrypted.Proof.Content, request.Proof.Content)
+	}
+}
@@ -0,0 +1,126 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+const FILE_SNIFF_SIZE = 512
+
+// FileDigest - Hash, size and detected MIME type of a file attribute
+type FileDigest struct {
+	Hash     string `json:"hash"`
+	Size     int64  `json:"size"`
+	MimeType string `json:"mime_type"`
+}
+
+// HashFile - Stream content through sha256 with bounded memory
+func HashFile(r io.Reader) (*FileDigest, error) {
+	h := sha256.New()
+	sniff := make([]byte, FILE_SNIFF_SIZE)
+
+	n, err := io.ReadFull(r, sniff)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	sniff = sniff[:n]
+	h.Write(sniff)
+
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return nil, err
+	}
+
+	mimeType := http.DetectContentType(sniff)
+	if i := strings.IndexByte(mimeType, ';'); i >= 0 {
+		mimeType = mimeType[:i]
+	}
+
+	return &FileDigest{
+		Hash:     hex.EncodeToString(h.Sum(nil)),
+		Size:     size + int64(n),
+		MimeType: mimeType,
+	}, nil
+}
+
+// HashFilePath - Stream file at path through sha256
+func HashFilePath(path string) (*FileDigest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return HashFile(f)
+}
+
+// VerifyFile - Check presented file content against the attribute hash of a credential
+func VerifyFile(r io.Reader, attribute *protocol.IssAttributeCredentialContentAttribute) error {
+	if attribute == nil {
+		return fmt.Errorf("credential has no attribute")
+	}
+
+	digest, err := HashFile(r)
+	if err != nil {
+		return err
+	}
+
+	if !strings.EqualFold(digest.Hash, attribute.Hash) {
+		return fmt.Errorf("file %s does not match credential hash", attribute.Name)
+	}
+	return nil
+}
+
+// VerifyFilePath - Check file at path against the attribute hash of a credential
+func VerifyFilePath(path string, attribute *protocol.IssAttributeCredentialContentAttribute) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return VerifyFile(f, attribute)
+}
+
+// hashFileAttributes - Compute file attribute hashes from their content instead of trusting the given value
+//
+// Attributes sent without content keep the digest computed by the caller, e.g. Issuance.File.
+func hashFileAttributes(request *protocol.IssueRequest) error {
+	for name, attribute := range request.Document.Attributes {
+		if attribute == nil || attribute.Content == nil || attribute.Content.Type != models.ATTRIBUTE_TYPE_FILE {
+			continue
+		}
+
+		attr, ok := request.Attributes[name]
+		if !ok || attr == nil || len(attr.Value) == 0 {
+			if attribute.Content.Value == "" {
+				return fmt.Errorf("file attribute %s has no content or hash", name)
+			}
+			continue
+		}
+
+		digest, err := HashFile(bytes.NewReader(attr.Value))
+		if err != nil {
+			return err
+		}
+
+		if attribute.Content.Value != "" && !strings.EqualFold(attribute.Content.Value, digest.Hash) {
+			return fmt.Errorf("file attribute %s does not match its content hash", name)
+		}
+
+		attribute.Content.Value = digest.Hash
+		if attribute.Content.Format == "" {
+			attribute.Content.Format = digest.MimeType
+		}
+	}
+	return nil
+}
@@ -2,25 +2,57 @@ package core
 
 import (
 	"encoding/base64"
+	"fmt"
+	"strings"
 
+	"github.com/anima-protocol/anima-go/chains/evm"
 	"github.com/anima-protocol/anima-go/models"
 	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/validators"
 )
 
 func GetIssuingAuthorization(request *protocol.IssueRequest) (*models.IssuingAuthorization, error) {
+	if request == nil || request.Document == nil || request.Document.Authorization == nil {
+		return nil, &validators.FieldError{Path: "document.authorization", Message: "is required"}
+	}
+
 	specs := request.Document.Authorization.Specs
 	encodedContent := request.Document.Authorization.Content
 	signature := request.Document.Authorization.Signature
 
+	extract, ok := ExtractIssuingAuthorization[specs]
+	if !ok {
+		return nil, &validators.FieldError{Path: "document.authorization.specs", Message: fmt.Sprintf("unknown issuing authorization specs %q", specs)}
+	}
+
 	content, err := base64.StdEncoding.DecodeString(encodedContent)
 	if err != nil {
-		return nil, err
+		return nil, &validators.FieldError{Path: "document.authorization.content", Message: "must be base64 encoded"}
 	}
 
-	issuingAuthorization, rErr := ExtractIssuingAuthorization[specs](content, signature)
+	issuingAuthorization, rErr := extract(content, signature)
 	if rErr != nil {
 		return nil, rErr
 	}
 
 	return issuingAuthorization, nil
 }
+
+// CreateIssuingAuthorization - Sign an issuing authorization as its owner, ready to embed in an IssueRequest
+func CreateIssuingAuthorization(authorization *models.IssuingAuthorization, signingFunc func([]byte) (string, error)) (*protocol.IssAuthorization, error) {
+	switch authorization.Owner.Chain {
+	case models.CHAIN_ETH:
+		content, signature, err := evm.SignIssuingAuthorizationEIP712(authorization, signingFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		return &protocol.IssAuthorization{
+			Specs:     ISSUING_AUTHORIZATION_EIP712,
+			Content:   base64.StdEncoding.EncodeToString(content),
+			Signature: "0x" + strings.TrimPrefix(signature, "0x"),
+		}, nil
+	}
+
+	return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+}
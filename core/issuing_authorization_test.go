@@ -0,0 +1,68 @@
+package core_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/validators"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestCreateIssuingAuthorization(t *testing.T) {
+	ownerKey, err := crypto.HexToECDSA(OWNER_KEY)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorization := &models.IssuingAuthorization{
+		Specs:       models.DOCUMENT_SPECS_PASSPORT,
+		RequestedAt: uint64(fixtureTime.Unix()),
+		Fields:      map[string]string{},
+		Attributes:  map[string]bool{"firstname": true},
+		Owner:       models.AnimaOwner{ID: "owner", PublicAddress: crypto.PubkeyToAddress(ownerKey.PublicKey).Hex(), Chain: models.CHAIN_ETH},
+		Issuer:      models.AnimaIssuer{ID: "issuer", PublicAddress: ISSUER_ADDRESS, Chain: models.CHAIN_ETH},
+	}
+
+	issAuthorization, err := core.CreateIssuingAuthorization(authorization, evm.PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if issAuthorization.Specs != core.ISSUING_AUTHORIZATION_EIP712 || !strings.HasPrefix(issAuthorization.Signature, "0x") {
+		t.Errorf("authorization = %+v", issAuthorization)
+	}
+
+	decoded, err := core.GetIssuingAuthorization(&protocol.IssueRequest{Document: &protocol.IssDocument{Authorization: issAuthorization}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if decoded.Owner.PublicAddress != authorization.Owner.PublicAddress || decoded.Issuer.ID != "issuer" || !decoded.Attributes["firstname"] {
+		t.Errorf("decoded = %+v", decoded)
+	}
+
+	authorization.Owner.Chain = "SOL"
+	if _, err := core.CreateIssuingAuthorization(authorization, evm.PrivateKeySigningFunc(ownerKey)); !errors.Is(err, models.ErrUnsupportedChain) {
+		t.Errorf("err = %v, want %v", err, models.ErrUnsupportedChain)
+	}
+}
+
+func TestGetIssuingAuthorizationFieldErrors(t *testing.T) {
+	for path, authorization := range map[string]*protocol.IssAuthorization{
+		"document.authorization":         nil,
+		"document.authorization.specs":   {Specs: "anima:specs:issuing/authorization/unknown@1.0.0"},
+		"document.authorization.content": {Specs: core.ISSUING_AUTHORIZATION_EIP712, Content: "not base64!"},
+	} {
+		_, err := core.GetIssuingAuthorization(&protocol.IssueRequest{Document: &protocol.IssDocument{Authorization: authorization}})
+
+		var fieldErr *validators.FieldError
+		if !errors.As(err, &fieldErr) || fieldErr.Path != path {
+			t.Errorf("err = %v, want a field error at %s", err, path)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/utils"
+	"github.com/anima-protocol/anima-go/validators"
+)
+
+// CreateSharingAuthorization - Sign a sharing authorization as its owner, ready to embed in a VerifyRequest
+func CreateSharingAuthorization(authorization *models.SharingAuthorization, signingFunc func([]byte) (string, error)) (*protocol.SharingAuthorization, error) {
+	message := *authorization
+	if message.Specs == "" {
+		message.Specs = SHARING_AUTHORIZATION
+	}
+
+	if !utils.InArray(message.Specs, AVAILABLE_SHARING_AUTHORIZATION) {
+		return nil, models.NewError(models.ErrInvalidRequest, "unknown sharing authorization specs %s", message.Specs)
+	}
+
+	content, err := json.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+
+	switch message.Owner.Chain {
+	case models.CHAIN_ETH:
+		anima := &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: crypto.SpecsVersion(message.Specs)}
+		signature, err := evm.SignSharingAuthorization(anima, content, signingFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		return &protocol.SharingAuthorization{
+			Specs:     message.Specs,
+			Content:   base64.StdEncoding.EncodeToString(content),
+			Signature: "0x" + strings.TrimPrefix(signature, "0x"),
+		}, nil
+	}
+
+	return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+}
+
+// GetSharingAuthorization - Decode a sharing authorization and verify its owner signature
+func GetSharingAuthorization(authorization *protocol.SharingAuthorization) (*models.SharingAuthorization, error) {
+	if authorization == nil {
+		return nil, &validators.FieldError{Path: "authorization", Message: "is required"}
+	}
+
+	if !utils.InArray(authorization.Specs, AVAILABLE_SHARING_AUTHORIZATION) {
+		return nil, &validators.FieldError{Path: "authorization.specs", Message: "unknown sharing authorization specs " + authorization.Specs}
+	}
+
+	content, err := base64.StdEncoding.DecodeString(authorization.Content)
+	if err != nil {
+		return nil, &validators.FieldError{Path: "authorization.content", Message: "must be base64 encoded"}
+	}
+
+	sharingAuthorization := &models.SharingAuthorization{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(sharingAuthorization); err != nil {
+		return nil, models.WrapError(models.ErrInvalidRequest, err, "invalid sharing authorization")
+	}
+
+	if sharingAuthorization.Specs != authorization.Specs {
+		return nil, &validators.FieldError{Path: "authorization.content.specs", Message: "does not match authorization specs"}
+	}
+
+	switch sharingAuthorization.Owner.Chain {
+	case models.CHAIN_ETH:
+		valid, err := evm.VerifySharingAuthorization(sharingAuthorization.Owner.PublicAddress, crypto.SpecsVersion(sharingAuthorization.Specs), content, authorization.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		if !valid {
+			return nil, models.NewError(models.ErrBadSignature, "invalid sharing authorization signature")
+		}
+	default:
+		return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+	}
+
+	return sharingAuthorization, nil
+}
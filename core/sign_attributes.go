@@ -1,39 +1,147 @@
 package core
 
 import (
-	"bytes"
+	"context"
 	"encoding/base64"
-	"encoding/json"
+	"encoding/hex"
 	"fmt"
 	"time"
 
 	"github.com/anima-protocol/anima-go/chains/evm"
 	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/logger"
 	"github.com/anima-protocol/anima-go/models"
 	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/telemetry"
+	"github.com/anima-protocol/anima-go/validators"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
 )
 
+// IssuingOptions - Options of SignIssuingWithOptions
+type IssuingOptions struct {
+	// Clock - Time source for issued_at and authorization freshness, time.Now when nil
+	Clock func() time.Time
+	// DryRun - Sign without sending the request to Anima Protocol
+	DryRun bool
+}
+
+// IssuingDigests - Intermediate digests computed while signing an IssueRequest
+type IssuingDigests struct {
+	ProofID     string                       `json:"proof_id"`
+	ProofEIP712 string                       `json:"proof_eip712"`
+	DocumentID  string                       `json:"document_id"`
+	Attributes  map[string]*AttributeDigests `json:"attributes"`
+}
+
+type AttributeDigests struct {
+	AttributeID  string `json:"attribute_id"`
+	CredentialID string `json:"credential_id"`
+	EIP712       string `json:"eip712"`
+}
+
+// IssuingResult - Signed copy of an IssueRequest and its digests
+type IssuingResult struct {
+	Request *protocol.IssueRequest `json:"request"`
+	Digests *IssuingDigests        `json:"digests"`
+}
+
 func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, signingFunc func([]byte) (string, error)) (*protocol.IssueRequest, error) {
+	result, err := SignIssuingWithOptions(anima, issuer, request, signingFunc, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return result.Request, nil
+}
+
+// SignIssuingWithOptions - Sign a copy of request, the given request is never mutated
+func SignIssuingWithOptions(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, signingFunc func([]byte) (string, error), opts *IssuingOptions) (*IssuingResult, error) {
+	return SignIssuingContext(context.Background(), anima, issuer, request, signingFunc, opts)
+}
+
+// SignIssuingContext - SignIssuingWithOptions with a caller context, traced under its span
+func SignIssuingContext(ctx context.Context, anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, signingFunc func([]byte) (string, error), opts *IssuingOptions) (result *IssuingResult, err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SPAN_SIGN_ISSUING, attribute.String("anima.chain", anima.Chain))
+	start := time.Now()
+	defer func() {
+		telemetry.RecordSigning(ctx, "sign_issuing", start, err)
+		telemetry.EndSpan(span, err)
+	}()
+
+	now := time.Now
+	if opts != nil && opts.Clock != nil {
+		now = opts.Clock
+	}
+
+	if err := validators.ValidateIssueRequest(request); err != nil {
+		return nil, err
+	}
+
+	if err := validators.ValidateIssuer(issuer); err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(
+		attribute.String("anima.document.specs", request.Document.Specs),
+		attribute.String("anima.proof.specs", request.Proof.Specs),
+		attribute.Int("anima.attributes", len(request.Attributes)),
+	)
+	logger.Debug("signing issuing request",
+		logger.String("chain", anima.Chain),
+		logger.String("document_specs", request.Document.Specs),
+		logger.String("proof_specs", request.Proof.Specs),
+		logger.Int("attributes", len(request.Attributes)),
+	)
+
+	request = proto.Clone(request).(*protocol.IssueRequest)
+	digests := &IssuingDigests{Attributes: make(map[string]*AttributeDigests)}
+
 	issuingAuthorization, err := GetIssuingAuthorization(request)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := CheckIssuingAuthorizationScope(anima, issuer, request, issuingAuthorization, now()); err != nil {
+		return nil, err
+	}
+
+	if err := hashFileAttributes(request); err != nil {
+		return nil, err
+	}
+
+	if err := validators.ValidateDocument(request, now()); err != nil {
+		return nil, err
+	}
+
+	// Legacy attribute ids hash values as given, so they are only normalized under later specs versions
+	validateAttributes := validators.NormalizeAttributes
+	if anima.GetSpecsVersion() == models.SPECS_VERSION_LEGACY {
+		validateAttributes = validators.ValidateAttributes
+	}
+
+	if err := validateAttributes(request); err != nil {
+		return nil, err
+	}
+
+	if _, err := validators.ValidateProof(request.Proof); err != nil {
+		return nil, err
+	}
+
 	// Sign Proof
 	proofContent, err := base64.StdEncoding.DecodeString(request.Proof.Content)
 	if err != nil {
 		return nil, err
 	}
 
-	proofContentBytes := new(bytes.Buffer)
-	err = json.Compact(proofContentBytes, proofContent)
+	proofContentBytes, err := crypto.Canonicalize(anima.SpecsVersion, proofContent)
 	if err != nil {
 		return nil, err
 	}
 
 	switch anima.Chain {
 	case models.CHAIN_ETH:
-		proofSignature, err := evm.SignProof(anima, proofContent, signingFunc)
+		proofSignature, err := signProof(ctx, anima, proofContent, recordDigest(signingFunc, &digests.ProofEIP712))
 		if err != nil {
 			return nil, err
 		}
@@ -41,7 +149,8 @@ func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *
 		request.Proof.Signature = "0x" + proofSignature
 	}
 
-	proofId := fmt.Sprintf("anima:proof:%s", crypto.Hash(proofContentBytes.Bytes()))
+	proofId := fmt.Sprintf("anima:proof:%s", crypto.Hash(proofContentBytes))
+	digests.ProofID = proofId
 
 	owner := &protocol.AnimaOwner{
 		Id:            issuingAuthorization.Owner.ID,
@@ -52,7 +161,9 @@ func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *
 
 	request.Document.Owner = owner
 
-	issuedAt := time.Now().Unix()
+	specsVersion := anima.GetSpecsVersion()
+
+	issuedAt := now().Unix()
 	// Sign Attributes
 	for name := range request.Attributes {
 		request.Attributes[name].Content = &protocol.IssDocumentAttributeContent{
@@ -65,19 +176,23 @@ func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *
 		}
 
 		contentHash := crypto.HashStr(request.Document.Attributes[name].Content.Value)
-		if request.Attributes[name].Content.Type == "file" {
+		if request.Attributes[name].Content.Type == models.ATTRIBUTE_TYPE_FILE {
 			contentHash = request.Document.Attributes[name].Content.Value
 		}
 
-		attrBytes, err := json.Marshal(request.Attributes[name].Content)
+		attrContentBytes, err := crypto.CanonicalJSON(anima.SpecsVersion, request.Attributes[name].Content)
 		if err != nil {
 			return nil, err
 		}
 
-		attrContentBytes := new(bytes.Buffer)
-		err = json.Compact(attrContentBytes, attrBytes)
-		if err != nil {
-			return nil, err
+		attributeDigests := &AttributeDigests{
+			AttributeID:  fmt.Sprintf("anima:attribute:%s", crypto.Hash(attrContentBytes)),
+			CredentialID: fmt.Sprintf("anima:credential:%s", crypto.Hash(attrContentBytes)),
+		}
+		digests.Attributes[name] = attributeDigests
+
+		if request.Attributes[name].Credential == nil {
+			request.Attributes[name].Credential = &protocol.IssAttributeCredential{}
 		}
 
 		request.Attributes[name].Credential.Content = &protocol.IssAttributeCredentialContent{
@@ -86,8 +201,8 @@ func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *
 			Owner:     owner,
 			Issuer:    issuer,
 			Attribute: &protocol.IssAttributeCredentialContentAttribute{
-				Specs: "anima:specs:attribute@1.0.0",
-				Id:    fmt.Sprintf("anima:attribute:%s", crypto.Hash(attrContentBytes.Bytes())),
+				Specs: fmt.Sprintf("anima:specs:attribute@%s", specsVersion),
+				Id:    attributeDigests.AttributeID,
 				Hash:  contentHash,
 				Name:  name,
 			},
@@ -98,31 +213,26 @@ func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *
 		}
 
 		request.Document.Attributes[name].Credential = &protocol.IssDocumentAttributeCredential{
-			Specs: "anima:specs:credential@1.0.0",
-			Id:    fmt.Sprintf("anima:credential:%s", crypto.Hash(attrContentBytes.Bytes())),
+			Specs: fmt.Sprintf("anima:specs:credential@%s", specsVersion),
+			Id:    attributeDigests.CredentialID,
 		}
 	}
 
-	for name := range request.Attributes {
-		documentBytes, err := json.Marshal(request.Document)
-		if err != nil {
-			return nil, err
-		}
-
-		documentContentBytes := new(bytes.Buffer)
-		err = json.Compact(documentContentBytes, documentBytes)
-		if err != nil {
-			return nil, err
-		}
+	documentContentBytes, err := crypto.CanonicalJSON(anima.SpecsVersion, request.Document)
+	if err != nil {
+		return nil, err
+	}
+	digests.DocumentID = fmt.Sprintf("anima:document:%s", crypto.Hash(documentContentBytes))
 
+	for name := range request.Attributes {
 		request.Attributes[name].Credential.Content.Document = &protocol.IssAttributeCredentialContentDocument{
 			Specs: request.Document.Specs,
-			Id:    fmt.Sprintf("anima:document:%s", crypto.Hash(documentContentBytes.Bytes())),
+			Id:    digests.DocumentID,
 		}
 
 		switch anima.Chain {
 		case models.CHAIN_ETH:
-			signature, err := evm.SignCredential(anima, request.Attributes[name].Credential.Content, signingFunc)
+			signature, err := signCredential(ctx, anima, name, request.Attributes[name].Credential.Content, recordDigest(signingFunc, &digests.Attributes[name].EIP712))
 			if err != nil {
 				return nil, err
 			}
@@ -131,5 +241,50 @@ func SignIssuing(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *
 		}
 	}
 
-	return request, nil
+	if anima.EncryptAttributes {
+		if err := encryptIssuing(request, issuingAuthorization.Owner.PublicKeyEncryption); err != nil {
+			return nil, err
+		}
+	}
+
+	telemetry.RecordAttributesSigned(ctx, len(request.Attributes), request.Document.Specs)
+	span.SetAttributes(attribute.String("anima.document.id", digests.DocumentID))
+	logger.Info("issuing request signed",
+		logger.String("chain", anima.Chain),
+		logger.String("document_specs", request.Document.Specs),
+		logger.String("document_id", digests.DocumentID),
+		logger.String("proof_id", digests.ProofID),
+	)
+
+	return &IssuingResult{Request: request, Digests: digests}, nil
+}
+
+func signProof(ctx context.Context, anima *models.Protocol, content []byte, signingFunc func([]byte) (string, error)) (signature string, err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SPAN_SIGN_PROOF)
+	start := time.Now()
+	defer func() {
+		telemetry.RecordSigning(ctx, "sign_proof", start, err)
+		telemetry.EndSpan(span, err)
+	}()
+
+	return evm.SignProof(anima, content, signingFunc)
+}
+
+func signCredential(ctx context.Context, anima *models.Protocol, name string, content *protocol.IssAttributeCredentialContent, signingFunc func([]byte) (string, error)) (signature string, err error) {
+	ctx, span := telemetry.StartSpan(ctx, telemetry.SPAN_SIGN_CREDENTIAL, attribute.String("anima.attribute.name", name))
+	start := time.Now()
+	defer func() {
+		telemetry.RecordSigning(ctx, "sign_credential", start, err)
+		telemetry.EndSpan(span, err)
+	}()
+
+	return evm.SignCredential(anima, content, signingFunc)
+}
+
+// recordDigest - Wrap signingFunc to keep the hex EIP-712 digest it signs
+func recordDigest(signingFunc func([]byte) (string, error), digest *string) func([]byte) (string, error) {
+	return func(data []byte) (string, error) {
+		*digest = "0x" + hex.EncodeToString(data)
+		return signingFunc(data)
+	}
 }
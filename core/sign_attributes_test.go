@@ -0,0 +1,213 @@
+package core_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var update = flag.Bool("update", false, "rewrite golden files in testdata")
+
+const (
+	/* FIXTURE KEYS */
+	OWNER_KEY  = "4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318"
+	ISSUER_KEY = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+)
+
+// fixtureTime - Clock of testdata/issue_request.json, one minute after its issuing authorization
+var fixtureTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func fixtureClock() time.Time {
+	return fixtureTime
+}
+
+// fixtureIssuer - Issuer authorized by testdata/issue_request.json and its signing function
+func fixtureIssuer(t *testing.T) (*protocol.AnimaIssuer, func([]byte) (string, error)) {
+	t.Helper()
+	key, err := crypto.HexToECDSA(ISSUER_KEY)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuer := &protocol.AnimaIssuer{Id: "issuer", PublicAddress: crypto.PubkeyToAddress(key.PublicKey).Hex(), Chain: models.CHAIN_ETH}
+	return issuer, evm.PrivateKeySigningFunc(key)
+}
+
+// fixtureRequest - Unsigned passport IssueRequest authorized by OWNER_KEY
+func fixtureRequest(t *testing.T) *protocol.IssueRequest {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", "issue_request.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request := &protocol.IssueRequest{}
+	if err := protojson.Unmarshal(b, request); err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
+
+type goldenAttribute struct {
+	AttributeID string `json:"attribute_id"`
+	Credential  string `json:"credential_id"`
+	Signature   string `json:"signature"`
+}
+
+// goldenIssuing - Ids and signatures of a signed IssueRequest
+type goldenIssuing struct {
+	ProofID        string                      `json:"proof_id"`
+	ProofSignature string                      `json:"proof_signature"`
+	DocumentID     string                      `json:"document_id"`
+	Attributes     map[string]*goldenAttribute `json:"attributes"`
+}
+
+func golden(t *testing.T, request *protocol.IssueRequest) *goldenIssuing {
+	t.Helper()
+	g := &goldenIssuing{ProofSignature: request.Proof.Signature, Attributes: make(map[string]*goldenAttribute)}
+	for name, attribute := range request.Attributes {
+		content := attribute.Credential.Content
+		if g.DocumentID != "" && g.DocumentID != content.Document.Id {
+			t.Errorf("%s: document id %s, want %s", name, content.Document.Id, g.DocumentID)
+		}
+
+		g.ProofID = content.Proof.Id
+		g.DocumentID = content.Document.Id
+		g.Attributes[name] = &goldenAttribute{
+			AttributeID: content.Attribute.Id,
+			Credential:  request.Document.Attributes[name].Credential.Id,
+			Signature:   attribute.Credential.Signature,
+		}
+	}
+	return g
+}
+
+// checkGolden - Compare got with testdata/name, or rewrite it with -update
+func checkGolden(t *testing.T, name string, got *goldenIssuing) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		b, err := json.MarshalIndent(got, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, append(b, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &goldenIssuing{}
+	if err := json.Unmarshal(b, want); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		b, _ := json.MarshalIndent(got, "", "  ")
+		t.Errorf("signed request does not match %s, got:\n%s", path, b)
+	}
+}
+
+func TestSignIssuingGolden(t *testing.T) {
+	// signed_1.0.0.json was produced by the baseline SignIssuing, legacy output must never change
+	for _, specsVersion := range []string{models.SPECS_VERSION_LEGACY, models.SPECS_VERSION_JCS} {
+		t.Run(specsVersion, func(t *testing.T) {
+			issuer, signingFunc := fixtureIssuer(t)
+			anima := &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: specsVersion}
+
+			result, err := core.SignIssuingWithOptions(anima, issuer, fixtureRequest(t), signingFunc, &core.IssuingOptions{Clock: fixtureClock})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			checkGolden(t, "signed_"+specsVersion+".json", golden(t, result.Request))
+		})
+	}
+}
+
+func TestSignIssuingWithOptionsDigests(t *testing.T) {
+	issuer, signingFunc := fixtureIssuer(t)
+	anima := &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: models.SPECS_VERSION_JCS}
+	request := fixtureRequest(t)
+	original := proto.Clone(request)
+
+	results := make([]*core.IssuingResult, 2)
+	for i := range results {
+		result, err := core.SignIssuingWithOptions(anima, issuer, request, signingFunc, &core.IssuingOptions{Clock: fixtureClock})
+		if err != nil {
+			t.Fatal(err)
+		}
+		results[i] = result
+	}
+
+	if !proto.Equal(request, original) {
+		t.Error("SignIssuingWithOptions changed the given request")
+	}
+
+	if !reflect.DeepEqual(results[0].Digests, results[1].Digests) || !proto.Equal(results[0].Request, results[1].Request) {
+		t.Error("signing twice with the same clock gave different results")
+	}
+
+	digests := results[0].Digests
+	path := filepath.Join("testdata", "digests_"+models.SPECS_VERSION_JCS+".json")
+	if *update {
+		b, err := json.MarshalIndent(digests, "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := os.WriteFile(path, append(b, '\n'), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &core.IssuingDigests{}
+	if err := json.Unmarshal(b, want); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(digests, want) {
+		b, _ := json.MarshalIndent(digests, "", "  ")
+		t.Errorf("digests do not match %s, got:\n%s", path, b)
+	}
+
+	signed := golden(t, results[0].Request)
+	if digests.ProofID != signed.ProofID || digests.DocumentID != signed.DocumentID {
+		t.Errorf("digests ids %s, %s do not match the signed request", digests.ProofID, digests.DocumentID)
+	}
+
+	for name, attribute := range signed.Attributes {
+		if digests.Attributes[name].AttributeID != attribute.AttributeID || digests.Attributes[name].CredentialID != attribute.Credential {
+			t.Errorf("%s: digests ids do not match the signed request", name)
+		}
+	}
+
+	for name, attribute := range results[0].Request.Attributes {
+		if attribute.Credential.Content.IssuedAt != fixtureTime.Unix() {
+			t.Errorf("%s: issued at %d, want clock time %d", name, attribute.Credential.Content.IssuedAt, fixtureTime.Unix())
+		}
+	}
+}
@@ -5,6 +5,14 @@ import (
 	"github.com/anima-protocol/anima-go/models"
 )
 
+const (
+	ISSUING_AUTHORIZATION_EIP712 = "anima:specs:issuing/authorization/eip712@1.0.0"
+	SHARING_AUTHORIZATION        = "anima:specs:sharing/authorization@1.0.0"
+	SHARING_AUTHORIZATION_JCS    = "anima:specs:sharing/authorization@1.1.0"
+)
+
+var AVAILABLE_SHARING_AUTHORIZATION = []string{SHARING_AUTHORIZATION, SHARING_AUTHORIZATION_JCS}
+
 var ExtractIssuingAuthorization = map[string]func([]byte, string) (*models.IssuingAuthorization, error){
-	"anima:specs:issuing/authorization/eip712@1.0.0": evm.GetIssuingAuthorizationEIP712,
+	ISSUING_AUTHORIZATION_EIP712: evm.GetIssuingAuthorizationEIP712,
 }
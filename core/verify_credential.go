@@ -0,0 +1,36 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+// VerifyCredential - Verify issuer signature of an attribute credential, 1.0.0 artifacts included
+func VerifyCredential(credential *protocol.IssAttributeCredential) error {
+	content := credential.Content
+	if content == nil || content.Issuer == nil || content.Attribute == nil {
+		return fmt.Errorf("incomplete credential content")
+	}
+
+	specsVersion := crypto.SpecsVersion(content.Attribute.Specs)
+
+	switch content.Issuer.Chain {
+	case models.CHAIN_ETH:
+		valid, err := evm.VerifyCredential(content.Issuer.PublicAddress, specsVersion, content, credential.Signature)
+		if err != nil {
+			return err
+		}
+
+		if !valid {
+			return models.NewError(models.ErrBadSignature, "invalid credential signature")
+		}
+	default:
+		return models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package crypto
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/crypto/jcs"
+	"github.com/anima-protocol/anima-go/models"
+)
+
+// Canonicalize - Serialize JSON content as hashed under specsVersion
+//
+// 1.0.0 artifacts were hashed over json.Compact output, later versions
+// use RFC 8785 canonical JSON.
+func Canonicalize(specsVersion string, content []byte) ([]byte, error) {
+	switch specsVersion {
+	case "", models.SPECS_VERSION_LEGACY:
+		contentBytes := new(bytes.Buffer)
+		if err := json.Compact(contentBytes, content); err != nil {
+			return nil, err
+		}
+		return contentBytes.Bytes(), nil
+	case models.SPECS_VERSION_JCS:
+		return jcs.Transform(content)
+	}
+	return nil, fmt.Errorf("unsupported specs version: %s", specsVersion)
+}
+
+// CanonicalJSON - Encode v and serialize it as hashed under specsVersion
+func CanonicalJSON(specsVersion string, v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(specsVersion, b)
+}
+
+// SpecsVersion - Version suffix of a specs identifier (anima:specs:credential@1.0.0)
+func SpecsVersion(specs string) string {
+	if i := strings.LastIndex(specs, "@"); i >= 0 {
+		return specs[i+1:]
+	}
+	return models.SPECS_VERSION_LEGACY
+}
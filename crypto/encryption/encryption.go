@@ -0,0 +1,186 @@
+// Package encryption encrypts attribute values to an owner public encryption key.
+//
+// X25519 keys follow MetaMask eth_getEncryptionPublicKey (base64 NaCl box
+// public key) and produce x25519-xsalsa20-poly1305 payloads that eth_decrypt
+// understands. secp256k1 keys (hex encoded) use ECIES as implemented by
+// go-ethereum.
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"golang.org/x/crypto/curve25519"
+	"golang.org/x/crypto/nacl/box"
+)
+
+const (
+	VERSION_X25519    = "x25519-xsalsa20-poly1305"
+	VERSION_SECP256K1 = "secp256k1-ecies-aes128-sha256"
+)
+
+type EncryptedData struct {
+	Version        string `json:"version"`
+	Nonce          string `json:"nonce,omitempty"`
+	EphemPublicKey string `json:"ephemPublicKey,omitempty"`
+	Ciphertext     string `json:"ciphertext"`
+}
+
+// Encrypt - Encrypt data to a base64 X25519 or hex secp256k1 public key
+func Encrypt(publicKey string, data []byte) (*EncryptedData, error) {
+	if b, err := base64.StdEncoding.DecodeString(publicKey); err == nil && len(b) == 32 {
+		key := [32]byte{}
+		copy(key[:], b)
+		return EncryptX25519(&key, data)
+	}
+
+	b, err := hex.DecodeString(strings.TrimPrefix(publicKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("unsupported encryption public key")
+	}
+
+	switch len(b) {
+	case 33:
+		pubKey, err := crypto.DecompressPubkey(b)
+		if err != nil {
+			return nil, err
+		}
+		return EncryptSecp256k1(ecies.ImportECDSAPublic(pubKey), data)
+	case 65:
+		pubKey, err := crypto.UnmarshalPubkey(b)
+		if err != nil {
+			return nil, err
+		}
+		return EncryptSecp256k1(ecies.ImportECDSAPublic(pubKey), data)
+	}
+
+	return nil, fmt.Errorf("unsupported encryption public key")
+}
+
+// EncryptX25519 - Encrypt data with NaCl box from a random ephemeral key
+func EncryptX25519(publicKey *[32]byte, data []byte) (*EncryptedData, error) {
+	ephemPublicKey, ephemPrivateKey, err := box.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := [24]byte{}
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return nil, err
+	}
+
+	ciphertext := box.Seal(nil, data, &nonce, publicKey, ephemPrivateKey)
+
+	return &EncryptedData{
+		Version:        VERSION_X25519,
+		Nonce:          base64.StdEncoding.EncodeToString(nonce[:]),
+		EphemPublicKey: base64.StdEncoding.EncodeToString(ephemPublicKey[:]),
+		Ciphertext:     base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// EncryptSecp256k1 - Encrypt data with ECIES
+func EncryptSecp256k1(publicKey *ecies.PublicKey, data []byte) (*EncryptedData, error) {
+	ciphertext, err := ecies.Encrypt(rand.Reader, publicKey, data, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &EncryptedData{
+		Version:    VERSION_SECP256K1,
+		Ciphertext: base64.StdEncoding.EncodeToString(ciphertext),
+	}, nil
+}
+
+// Decrypt - Decrypt data with the owner private key
+//
+// The same 32 bytes private key decrypts both versions, as MetaMask derives
+// its X25519 key from the account private key.
+func Decrypt(privateKey []byte, encrypted *EncryptedData) ([]byte, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encrypted.Ciphertext)
+	if err != nil {
+		return nil, err
+	}
+
+	switch encrypted.Version {
+	case VERSION_X25519:
+		nonce, err := base64.StdEncoding.DecodeString(encrypted.Nonce)
+		if err != nil || len(nonce) != 24 {
+			return nil, fmt.Errorf("invalid nonce")
+		}
+
+		ephemPublicKey, err := base64.StdEncoding.DecodeString(encrypted.EphemPublicKey)
+		if err != nil || len(ephemPublicKey) != 32 {
+			return nil, fmt.Errorf("invalid ephemeral public key")
+		}
+
+		if len(privateKey) != 32 {
+			return nil, fmt.Errorf("invalid private key length: %d", len(privateKey))
+		}
+
+		n, pub, prv := [24]byte{}, [32]byte{}, [32]byte{}
+		copy(n[:], nonce)
+		copy(pub[:], ephemPublicKey)
+		copy(prv[:], privateKey)
+
+		data, ok := box.Open(nil, ciphertext, &n, &pub, &prv)
+		if !ok {
+			return nil, fmt.Errorf("decryption failed")
+		}
+		return data, nil
+	case VERSION_SECP256K1:
+		prv, err := crypto.ToECDSA(privateKey)
+		if err != nil {
+			return nil, err
+		}
+		return ecies.ImportECDSA(prv).Decrypt(ciphertext, nil, nil)
+	}
+
+	return nil, fmt.Errorf("unsupported encryption version: %s", encrypted.Version)
+}
+
+// PublicKeyX25519 - MetaMask eth_getEncryptionPublicKey of a private key
+func PublicKeyX25519(privateKey []byte) (string, error) {
+	publicKey, err := curve25519.X25519(privateKey, curve25519.Basepoint)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// Marshal - Encode encrypted data as JSON
+func (e *EncryptedData) Marshal() ([]byte, error) {
+	return json.Marshal(e)
+}
+
+// MetaMaskHex - Encode encrypted data as expected by eth_decrypt
+func (e *EncryptedData) MetaMaskHex() (string, error) {
+	b, err := e.Marshal()
+	if err != nil {
+		return "", err
+	}
+	return "0x" + hex.EncodeToString(b), nil
+}
+
+// Unmarshal - Decode JSON or eth_decrypt hex encrypted data
+func Unmarshal(data []byte) (*EncryptedData, error) {
+	if s := string(data); strings.HasPrefix(s, "0x") {
+		b, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, err
+		}
+		data = b
+	}
+
+	encrypted := EncryptedData{}
+	if err := json.Unmarshal(data, &encrypted); err != nil {
+		return nil, err
+	}
+	return &encrypted, nil
+}
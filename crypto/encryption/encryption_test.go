@@ -0,0 +1,149 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// MetaMask eth-sig-util encryption example
+const (
+	METAMASK_PRIVATE_KEY = "7e5374ec2ef0d91761a6e72fdf8f6ac665519bfdf6da0a2329cf0d804514b816"
+	METAMASK_PUBLIC_KEY  = "C5YMNdqE4kLgxQhJO1MfuQcHP5hjVSXzamzd/TxlR0U="
+	METAMASK_ENCRYPTED   = `{"version":"x25519-xsalsa20-poly1305","nonce":"1dvWO7uOnBnO7iNDJ9kO9pTasLuKNlej","ephemPublicKey":"FBH1/pAEHOOW14Lu3FWkgV3qOEcuL78Zy+qW1RwzMXQ=","ciphertext":"f8kBcl/NCyf3sybfbwAKk/np2Bzt9lRVkZejr6uh5FgnNlH/ic62DZzy"}`
+	METAMASK_PLAINTEXT   = "My name is Satoshi Buterin"
+)
+
+func metaMaskKey(t *testing.T) []byte {
+	t.Helper()
+	privateKey, err := hex.DecodeString(METAMASK_PRIVATE_KEY)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return privateKey
+}
+
+func TestPublicKeyX25519MatchesMetaMask(t *testing.T) {
+	publicKey, err := PublicKeyX25519(metaMaskKey(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if publicKey != METAMASK_PUBLIC_KEY {
+		t.Errorf("public key = %s, want %s", publicKey, METAMASK_PUBLIC_KEY)
+	}
+}
+
+func TestDecryptMetaMask(t *testing.T) {
+	// eth_decrypt takes the JSON payload hex encoded
+	for name, data := range map[string]string{
+		"json": METAMASK_ENCRYPTED,
+		"hex":  "0x" + hex.EncodeToString([]byte(METAMASK_ENCRYPTED)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			encrypted, err := Unmarshal([]byte(data))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			plaintext, err := Decrypt(metaMaskKey(t), encrypted)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(plaintext) != METAMASK_PLAINTEXT {
+				t.Errorf("plaintext = %q", plaintext)
+			}
+		})
+	}
+}
+
+func TestX25519RoundTrip(t *testing.T) {
+	data := []byte("Jane")
+	encrypted, err := Encrypt(METAMASK_PUBLIC_KEY, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if encrypted.Version != VERSION_X25519 {
+		t.Errorf("version = %s", encrypted.Version)
+	}
+
+	encoded, err := encrypted.MetaMaskHex()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded, err := Unmarshal([]byte(encoded))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plaintext, err := Decrypt(metaMaskKey(t), decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(plaintext, data) {
+		t.Errorf("plaintext = %q, want %q", plaintext, data)
+	}
+
+	otherKey := make([]byte, 32)
+	if _, err := Decrypt(otherKey, decoded); err == nil {
+		t.Error("decrypted with another private key")
+	}
+}
+
+func TestSecp256k1RoundTrip(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey := crypto.FromECDSA(key)
+
+	for name, publicKey := range map[string]string{
+		"compressed":   hex.EncodeToString(crypto.CompressPubkey(&key.PublicKey)),
+		"uncompressed": "0x" + hex.EncodeToString(crypto.FromECDSAPub(&key.PublicKey)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			data := []byte("Jane")
+			encrypted, err := Encrypt(publicKey, data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if encrypted.Version != VERSION_SECP256K1 {
+				t.Errorf("version = %s", encrypted.Version)
+			}
+
+			b, err := encrypted.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			decoded, err := Unmarshal(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			plaintext, err := Decrypt(privateKey, decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(plaintext, data) {
+				t.Errorf("plaintext = %q, want %q", plaintext, data)
+			}
+		})
+	}
+}
+
+func TestEncryptRejectsUnsupportedKeys(t *testing.T) {
+	for _, publicKey := range []string{"", "not a key", "0x1234", "AAAA"} {
+		if _, err := Encrypt(publicKey, []byte("Jane")); err == nil {
+			t.Errorf("encrypted to %q", publicKey)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Package jcs implements the JSON Canonicalization Scheme (RFC 8785).
+//
+// Anima payloads are serialized with their protobuf JSON field names and
+// zero values omitted before canonicalization, so any language producing
+// the same members gets the same bytes and therefore the same hashes.
+// Cross-language test vectors are provided in testdata/vectors.json.
+package jcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Marshal - Encode v as canonical JSON
+func Marshal(v interface{}) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return Transform(b)
+}
+
+// Transform - Canonicalize JSON document
+func Transform(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if decoder.More() {
+		return nil, fmt.Errorf("jcs: trailing data after json value")
+	}
+
+	buf := new(bytes.Buffer)
+	if err := encode(buf, value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func encode(buf *bytes.Buffer, value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		n, err := formatNumber(v)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(n)
+	case string:
+		encodeString(buf, v)
+	case []interface{}:
+		buf.WriteByte('[')
+		for i, item := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := encode(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			return lessUTF16(keys[i], keys[j])
+		})
+
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			encodeString(buf, key)
+			buf.WriteByte(':')
+			if err := encode(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		return fmt.Errorf("jcs: unsupported type %T", value)
+	}
+	return nil
+}
+
+// formatNumber - Serialize number as ECMAScript Number.prototype.toString
+func formatNumber(number json.Number) (string, error) {
+	f, err := strconv.ParseFloat(number.String(), 64)
+	if err != nil {
+		return "", fmt.Errorf("jcs: invalid number %s", number)
+	}
+
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("jcs: number out of range %s", number)
+	}
+
+	if f == 0 {
+		return "0", nil
+	}
+
+	sign := ""
+	if f < 0 {
+		sign = "-"
+		f = -f
+	}
+
+	if f >= 1e-6 && f < 1e21 {
+		return sign + strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+
+	s := strconv.FormatFloat(f, 'e', -1, 64)
+	mantissa, exponent := s[:strings.IndexByte(s, 'e')], s[strings.IndexByte(s, 'e')+1:]
+	expSign := exponent[:1]
+	exponent = strings.TrimLeft(exponent[1:], "0")
+	return sign + mantissa + "e" + expSign + exponent, nil
+}
+
+func encodeString(buf *bytes.Buffer, s string) {
+	buf.WriteByte('"')
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == '"':
+			buf.WriteString(`\"`)
+		case r == '\\':
+			buf.WriteString(`\\`)
+		case r == '\b':
+			buf.WriteString(`\b`)
+		case r == '\f':
+			buf.WriteString(`\f`)
+		case r == '\n':
+			buf.WriteString(`\n`)
+		case r == '\r':
+			buf.WriteString(`\r`)
+		case r == '\t':
+			buf.WriteString(`\t`)
+		case r < 0x20:
+			fmt.Fprintf(buf, `\u%04x`, r)
+		default:
+			buf.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	buf.WriteByte('"')
+}
+
+func lessUTF16(a string, b string) bool {
+	ua := utf16.Encode([]rune(a))
+	ub := utf16.Encode([]rune(b))
+	for i := 0; i < len(ua) && i < len(ub); i++ {
+		if ua[i] != ub[i] {
+			return ua[i] < ub[i]
+		}
+	}
+	return len(ua) < len(ub)
+}
@@ -0,0 +1,56 @@
+package jcs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
+
+type vector struct {
+	Name     string `json:"name"`
+	Input    string `json:"input"`
+	Expected string `json:"expected"`
+	Sha256   string `json:"sha256"`
+}
+
+func TestVectors(t *testing.T) {
+	content, err := ioutil.ReadFile("testdata/vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	vectors := []vector{}
+	if err := json.Unmarshal(content, &vectors); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(vectors) == 0 {
+		t.Fatal("no test vectors")
+	}
+
+	for _, v := range vectors {
+		t.Run(v.Name, func(t *testing.T) {
+			canonical, err := Transform([]byte(v.Input))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(canonical) != v.Expected {
+				t.Errorf("got %s, want %s", canonical, v.Expected)
+			}
+
+			digest := sha256.Sum256(canonical)
+			if hex.EncodeToString(digest[:]) != v.Sha256 {
+				t.Errorf("sha256 = %x, want %s", digest, v.Sha256)
+			}
+		})
+	}
+}
+
+func TestTransformRejectsTrailingData(t *testing.T) {
+	if _, err := Transform([]byte(`{"a":1} {"b":2}`)); err == nil {
+		t.Error("accepted trailing data")
+	}
+}
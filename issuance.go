@@ -0,0 +1,253 @@
+package anima
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/validators"
+	"google.golang.org/protobuf/proto"
+)
+
+// Issuance - Fluent builder of a consistent IssueRequest
+type Issuance struct {
+	request *protocol.IssueRequest
+	opts    *core.IssuingOptions
+	errs    []error
+}
+
+// NewIssuance - Start building a new IssueRequest
+func NewIssuance() *Issuance {
+	return &Issuance{
+		request: &protocol.IssueRequest{
+			Document: &protocol.IssDocument{
+				Attributes: make(map[string]*protocol.IssDocumentAttribute),
+			},
+			Attributes: make(map[string]*protocol.IssAttribute),
+			Proof:      &protocol.IssProof{},
+		},
+	}
+}
+
+// Document - Set document specs
+func (b *Issuance) Document(specs string) *Issuance {
+	b.request.Document.Specs = specs
+	return b
+}
+
+// ExpiresAt - Set document expiration
+func (b *Issuance) ExpiresAt(expiresAt time.Time) *Issuance {
+	b.request.Document.ExpiresAt = expiresAt.Unix()
+	return b
+}
+
+// Owner - Set issuing authorization signed by the owner
+func (b *Issuance) Owner(authorization *protocol.IssAuthorization) *Issuance {
+	b.request.Document.Authorization = authorization
+	return b
+}
+
+// Attribute - Add a typed attribute (string, integer, boolean or time.Time date)
+func (b *Issuance) Attribute(name string, value interface{}) *Issuance {
+	var content, attrType, format string
+
+	switch v := value.(type) {
+	case string:
+		content, attrType, format = v, models.ATTRIBUTE_TYPE_STRING, "text"
+	case bool:
+		content, attrType, format = strconv.FormatBool(v), models.ATTRIBUTE_TYPE_BOOLEAN, "boolean"
+	case int:
+		content, attrType, format = strconv.FormatInt(int64(v), 10), models.ATTRIBUTE_TYPE_INTEGER, "decimal"
+	case int32:
+		content, attrType, format = strconv.FormatInt(int64(v), 10), models.ATTRIBUTE_TYPE_INTEGER, "decimal"
+	case int64:
+		content, attrType, format = strconv.FormatInt(v, 10), models.ATTRIBUTE_TYPE_INTEGER, "decimal"
+	case uint:
+		content, attrType, format = strconv.FormatUint(uint64(v), 10), models.ATTRIBUTE_TYPE_INTEGER, "decimal"
+	case uint64:
+		content, attrType, format = strconv.FormatUint(v, 10), models.ATTRIBUTE_TYPE_INTEGER, "decimal"
+	case time.Time:
+		content, attrType, format = v.UTC().Format("2006-01-02"), models.ATTRIBUTE_TYPE_DATE, "iso-8601"
+	default:
+		b.errs = append(b.errs, fmt.Errorf("attribute %s: unsupported type %T", name, value))
+		return b
+	}
+
+	return b.add(name, content, attrType, format, []byte(content))
+}
+
+// File - Add a file attribute, content is streamed through the hasher and only its digest is kept
+func (b *Issuance) File(name string, r io.Reader) *Issuance {
+	digest, err := core.HashFile(r)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("attribute %s: %v", name, err))
+		return b
+	}
+
+	return b.FileDigest(name, digest)
+}
+
+// FileDigest - Add a file attribute from an already computed digest
+func (b *Issuance) FileDigest(name string, digest *core.FileDigest) *Issuance {
+	if digest == nil || digest.Hash == "" {
+		b.errs = append(b.errs, fmt.Errorf("attribute %s: file digest is required", name))
+		return b
+	}
+
+	return b.add(name, digest.Hash, models.ATTRIBUTE_TYPE_FILE, digest.MimeType, nil)
+}
+
+// FilePath - Add a file attribute read from path
+func (b *Issuance) FilePath(name string, path string) *Issuance {
+	f, err := os.Open(path)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("attribute %s: %v", name, err))
+		return b
+	}
+	defer f.Close()
+
+	return b.File(name, f)
+}
+
+// Options - Set issuing options, their clock is also used to validate the built request
+func (b *Issuance) Options(opts *core.IssuingOptions) *Issuance {
+	b.opts = opts
+	return b
+}
+
+// Proof - Set proof specs and JSON content
+func (b *Issuance) Proof(specs string, content interface{}) *Issuance {
+	c, err := json.Marshal(content)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("proof: %v", err))
+		return b
+	}
+
+	b.request.Proof = &protocol.IssProof{
+		Specs:   specs,
+		Content: base64.StdEncoding.EncodeToString(c),
+	}
+	return b
+}
+
+// Build - Validate and return the IssueRequest
+func (b *Issuance) Build() (*protocol.IssueRequest, error) {
+	if len(b.errs) > 0 {
+		return nil, b.errs[0]
+	}
+
+	// Later builder calls must not change a request already returned
+	request := proto.Clone(b.request).(*protocol.IssueRequest)
+	if request.Document.Specs == "" {
+		return nil, fmt.Errorf("document specs is required")
+	}
+
+	now := b.now()
+	if request.Document.ExpiresAt <= now.Unix() {
+		return nil, fmt.Errorf("document expiration must be in the future")
+	}
+
+	if request.Document.Authorization == nil || request.Document.Authorization.Content == "" || request.Document.Authorization.Signature == "" {
+		return nil, fmt.Errorf("owner issuing authorization is required")
+	}
+
+	if _, ok := core.ExtractIssuingAuthorization[request.Document.Authorization.Specs]; !ok {
+		return nil, fmt.Errorf("unsupported issuing authorization specs: %s", request.Document.Authorization.Specs)
+	}
+
+	if _, err := core.GetIssuingAuthorization(request); err != nil {
+		return nil, err
+	}
+
+	if len(request.Attributes) == 0 {
+		return nil, fmt.Errorf("at least one attribute is required")
+	}
+
+	if request.Proof.Specs == "" || request.Proof.Content == "" {
+		return nil, fmt.Errorf("proof is required")
+	}
+
+	specsAttributeTypes(request)
+
+	if err := validators.ValidateDocument(request, now); err != nil {
+		return nil, err
+	}
+
+	if err := validators.ValidateAttributes(request); err != nil {
+		return nil, err
+	}
+
+	if _, err := validators.ValidateProof(request.Proof); err != nil {
+		return nil, err
+	}
+
+	return request, nil
+}
+
+// Issue - Build the IssueRequest and issue it to Anima Protocol
+func (b *Issuance) Issue(anima *models.Protocol, issuer *protocol.AnimaIssuer) error {
+	request, err := b.Build()
+	if err != nil {
+		return err
+	}
+
+	_, err = IssueWithOptions(anima, issuer, request, b.opts)
+	return err
+}
+
+func (b *Issuance) now() time.Time {
+	if b.opts != nil && b.opts.Clock != nil {
+		return b.opts.Clock()
+	}
+	return time.Now()
+}
+
+// specsAttributeTypes - Give string attributes the type their document specs declares, e.g. country
+func specsAttributeTypes(request *protocol.IssueRequest) {
+	spec, ok := validators.GetDocumentSpec(request.Document.Specs)
+	if !ok {
+		return
+	}
+
+	for name, attribute := range request.Document.Attributes {
+		specsAttribute, ok := spec.Attributes[name]
+		if !ok || attribute.Content.Type != models.ATTRIBUTE_TYPE_STRING || specsAttribute.Type == models.ATTRIBUTE_TYPE_STRING {
+			continue
+		}
+
+		schema, ok := validators.GetAttributeSchema(specsAttribute.Type)
+		if !ok || specsAttribute.Type == models.ATTRIBUTE_TYPE_FILE {
+			continue
+		}
+
+		attribute.Content.Type = specsAttribute.Type
+		attribute.Content.Format = schema.Formats[0]
+	}
+}
+
+func (b *Issuance) add(name string, content string, attrType string, format string, value []byte) *Issuance {
+	if _, ok := b.request.Attributes[name]; ok {
+		b.errs = append(b.errs, fmt.Errorf("attribute %s: already set", name))
+		return b
+	}
+
+	b.request.Document.Attributes[name] = &protocol.IssDocumentAttribute{
+		Content: &protocol.IssDocumentAttributeContent{
+			Value:  content,
+			Type:   attrType,
+			Format: format,
+			Name:   name,
+		},
+	}
+	b.request.Attributes[name] = &protocol.IssAttribute{
+		Value: value,
+	}
+	return b
+}
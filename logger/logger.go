@@ -0,0 +1,118 @@
+package logger
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+// Level - Severity of a record, same values as slog levels
+type Level int
+
+const (
+	LEVEL_DEBUG Level = -4
+	LEVEL_INFO  Level = 0
+	LEVEL_WARN  Level = 4
+	LEVEL_ERROR Level = 8
+)
+
+func (l Level) String() string {
+	switch {
+	case l < LEVEL_INFO:
+		return "DEBUG"
+	case l < LEVEL_WARN:
+		return "INFO"
+	case l < LEVEL_ERROR:
+		return "WARN"
+	}
+	return "ERROR"
+}
+
+// Attr - Structured field of a record
+type Attr struct {
+	Key   string
+	Value interface{}
+}
+
+func String(key string, value string) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+func Int(key string, value int) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+func Any(key string, value interface{}) Attr {
+	return Attr{Key: key, Value: value}
+}
+
+// Err - Kind of err, its message may carry personal data and is never logged
+func Err(err error) Attr {
+	return Attr{Key: "error", Value: models.ErrorKind(err)}
+}
+
+// Record - Log entry handed to a Handler
+type Record struct {
+	Time    time.Time
+	Level   Level
+	Message string
+	Attrs   []Attr
+}
+
+// Handler - Receiver of log records, shaped like slog.Handler
+type Handler interface {
+	Enabled(ctx context.Context, level Level) bool
+	Handle(ctx context.Context, record Record) error
+}
+
+var (
+	handler   Handler
+	handlerMu sync.RWMutex
+)
+
+// SetHandler - Route SDK logs to handler, nil silences them (default)
+func SetHandler(h Handler) {
+	handlerMu.Lock()
+	defer handlerMu.Unlock()
+	handler = h
+}
+
+func Debug(msg string, attrs ...Attr) {
+	log(context.Background(), LEVEL_DEBUG, msg, attrs)
+}
+
+func Info(msg string, attrs ...Attr) {
+	log(context.Background(), LEVEL_INFO, msg, attrs)
+}
+
+func Warn(msg string, attrs ...Attr) {
+	log(context.Background(), LEVEL_WARN, msg, attrs)
+}
+
+func Error(msg string, attrs ...Attr) {
+	log(context.Background(), LEVEL_ERROR, msg, attrs)
+}
+
+// Log - Emit a record with a caller context, e.g. to carry trace ids
+func Log(ctx context.Context, level Level, msg string, attrs ...Attr) {
+	log(ctx, level, msg, attrs)
+}
+
+func log(ctx context.Context, level Level, msg string, attrs []Attr) {
+	handlerMu.RLock()
+	h := handler
+	handlerMu.RUnlock()
+
+	if h == nil || !h.Enabled(ctx, level) {
+		return
+	}
+
+	record := Record{Time: time.Now(), Level: level, Message: msg, Attrs: make([]Attr, 0, len(attrs))}
+	for _, attr := range attrs {
+		record.Attrs = append(record.Attrs, Redact(attr))
+	}
+
+	_ = h.Handle(ctx, record)
+}
@@ -0,0 +1,126 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+type recordHandler struct {
+	level   Level
+	records []Record
+}
+
+func (h *recordHandler) Enabled(_ context.Context, level Level) bool {
+	return level >= h.level
+}
+
+func (h *recordHandler) Handle(_ context.Context, record Record) error {
+	h.records = append(h.records, record)
+	return nil
+}
+
+func setHandler(t *testing.T, h Handler) {
+	t.Helper()
+	SetHandler(h)
+	t.Cleanup(func() { SetHandler(nil) })
+}
+
+func TestSilentByDefault(t *testing.T) {
+	// Nothing to observe but a panic on a nil handler
+	Error("no handler", String("key", "value"))
+}
+
+func TestLevels(t *testing.T) {
+	h := &recordHandler{level: LEVEL_WARN}
+	setHandler(t, h)
+
+	Debug("debug")
+	Info("info")
+	Warn("warn")
+	Error("error")
+	Log(context.Background(), LEVEL_ERROR+1, "above error")
+
+	var messages []string
+	for _, record := range h.records {
+		messages = append(messages, record.Level.String()+":"+record.Message)
+	}
+	if want := "WARN:warn,ERROR:error,ERROR:above error"; strings.Join(messages, ",") != want {
+		t.Errorf("records = %v, want %s", messages, want)
+	}
+}
+
+func TestRedact(t *testing.T) {
+	h := &recordHandler{level: LEVEL_DEBUG}
+	setHandler(t, h)
+
+	Info("redacted",
+		String("firstname", "Satoshi"),
+		String("public_address", "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"),
+		String("owner", "0xshort"),
+		Any("value", []byte("secret")),
+		String("method", "/anima.Anima/Issue"),
+	)
+
+	got := map[string]interface{}{}
+	for _, attr := range h.records[0].Attrs {
+		got[attr.Key] = attr.Value
+	}
+
+	for key, want := range map[string]interface{}{
+		"firstname":      REDACTED,
+		"public_address": "0xf39F...2266",
+		"owner":          REDACTED,
+		"value":          REDACTED,
+		"method":         "/anima.Anima/Issue",
+	} {
+		if got[key] != want {
+			t.Errorf("%s = %v, want %v", key, got[key], want)
+		}
+	}
+}
+
+func TestErrLogsKindOnly(t *testing.T) {
+	h := &recordHandler{level: LEVEL_DEBUG}
+	setHandler(t, h)
+
+	for err, want := range map[error]string{
+		models.NewError(models.ErrInvalidRequest, "attribute firstname: Satoshi is too long"):            models.ErrInvalidRequest.Error(),
+		models.WrapError(models.ErrNetworkUnavailable, errors.New("dial 10.0.0.1"), "could not connect"): models.ErrNetworkUnavailable.Error(),
+		errors.New("owner satoshi@example.com rejected"):                                                 "error",
+	} {
+		Warn("failed", Err(err))
+		if got := h.records[len(h.records)-1].Attrs[0].Value; got != want {
+			t.Errorf("error = %v, want %s", got, want)
+		}
+	}
+}
+
+func TestTextHandler(t *testing.T) {
+	out := &bytes.Buffer{}
+	h := NewTextHandler(out, LEVEL_INFO)
+
+	if h.Enabled(context.Background(), LEVEL_DEBUG) || !h.Enabled(context.Background(), LEVEL_INFO) {
+		t.Error("text handler level not applied")
+	}
+
+	record := Record{
+		Time:    time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		Level:   LEVEL_WARN,
+		Message: "anima protocol request failed",
+		Attrs:   []Attr{String("method", "issue"), Int("attempt", 2)},
+	}
+	if err := h.Handle(context.Background(), record); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `time=2024-06-01T12:00:00Z level=WARN msg="anima protocol request failed" method=issue attempt=2` + "\n"
+	if out.String() != want {
+		t.Errorf("line = %q, want %q", out.String(), want)
+	}
+}
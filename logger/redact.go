@@ -0,0 +1,35 @@
+package logger
+
+import "strings"
+
+const REDACTED = "[REDACTED]"
+
+// RedactedKeys - Attribute keys holding personal data, never logged in clear
+var RedactedKeys = map[string]bool{
+	"value":             true,
+	"content":           true,
+	"owner":             true,
+	"owner_id":          true,
+	"email":             true,
+	"phone":             true,
+	"firstname":         true,
+	"lastname":          true,
+	"birth_date":        true,
+	"private_key":       true,
+	"public_key":        true,
+	"public_address":    true,
+	"recovered_address": true,
+}
+
+// Redact - Hide personal data of an attribute, addresses keep their first and last characters
+var Redact = func(attr Attr) Attr {
+	if !RedactedKeys[attr.Key] {
+		return attr
+	}
+
+	if address, ok := attr.Value.(string); ok && strings.HasPrefix(address, "0x") && len(address) == 42 {
+		return Attr{Key: attr.Key, Value: address[:6] + "..." + address[38:]}
+	}
+
+	return Attr{Key: attr.Key, Value: REDACTED}
+}
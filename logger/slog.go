@@ -0,0 +1,30 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SlogHandler - Adapt a log/slog handler to the SDK logger
+func SlogHandler(h slog.Handler) Handler {
+	return &slogHandler{handler: h}
+}
+
+type slogHandler struct {
+	handler slog.Handler
+}
+
+func (h *slogHandler) Enabled(ctx context.Context, level Level) bool {
+	return h.handler.Enabled(ctx, slog.Level(level))
+}
+
+func (h *slogHandler) Handle(ctx context.Context, record Record) error {
+	r := slog.NewRecord(record.Time, slog.Level(record.Level), record.Message, 0)
+	for _, attr := range record.Attrs {
+		r.AddAttrs(slog.Any(attr.Key, attr.Value))
+	}
+	return h.handler.Handle(ctx, r)
+}
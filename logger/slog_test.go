@@ -0,0 +1,28 @@
+//go:build go1.21
+// +build go1.21
+
+package logger
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestSlogHandler(t *testing.T) {
+	out := &bytes.Buffer{}
+	setHandler(t, SlogHandler(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	Debug("filtered")
+	Info("anima client connecting", String("network", "localhost:50051"), String("lastname", "Buterin"))
+
+	line := out.String()
+	if strings.Contains(line, "filtered") || strings.Contains(line, "Buterin") {
+		t.Errorf("line = %q", line)
+	}
+
+	if !strings.Contains(line, `msg="anima client connecting" network=localhost:50051 lastname=[REDACTED]`) {
+		t.Errorf("line = %q", line)
+	}
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TextHandler - Minimal key=value handler for services without slog
+type TextHandler struct {
+	w     io.Writer
+	level Level
+	mu    sync.Mutex
+}
+
+func NewTextHandler(w io.Writer, level Level) *TextHandler {
+	return &TextHandler{w: w, level: level}
+}
+
+func (h *TextHandler) Enabled(_ context.Context, level Level) bool {
+	return level >= h.level
+}
+
+func (h *TextHandler) Handle(_ context.Context, record Record) error {
+	line := strings.Builder{}
+	fmt.Fprintf(&line, "time=%s level=%s msg=%q", record.Time.Format(time.RFC3339), record.Level, record.Message)
+	for _, attr := range record.Attrs {
+		fmt.Fprintf(&line, " %s=%v", attr.Key, attr.Value)
+	}
+	line.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, line.String())
+	return err
+}
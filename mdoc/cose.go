@@ -0,0 +1,121 @@
+package mdoc
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/fxamacker/cbor/v2"
+)
+
+func sigStructure(protected []byte, payload []byte) ([]byte, error) {
+	return encMode.Marshal([]interface{}{"Signature1", protected, []byte{}, payload})
+}
+
+func signCose(anima *models.Protocol, issuer *protocol.AnimaIssuer, payload []byte) (*CoseSign1, error) {
+	if anima == nil || anima.SigningFunc == nil {
+		return nil, models.NewError(models.ErrInvalidConfig, "issuer signing function is required")
+	}
+
+	if issuer == nil || issuer.PublicAddress == "" {
+		return nil, models.NewError(models.ErrInvalidRequest, "issuer is required")
+	}
+
+	protected, err := encMode.Marshal(map[int]interface{}{COSE_HEADER_ALG: COSE_ALG_ES256K})
+	if err != nil {
+		return nil, err
+	}
+
+	toBeSigned, err := sigStructure(protected, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(toBeSigned)
+	signature, err := anima.SigningFunc(digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sig) != 65 {
+		return nil, fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	return &CoseSign1{
+		Protected:   protected,
+		Unprotected: map[int]interface{}{COSE_HEADER_KID: []byte(issuer.PublicAddress)},
+		Payload:     payload,
+		Signature:   sig[:64],
+	}, nil
+}
+
+func verifyCose(sign1 *CoseSign1, publicAddress string) error {
+	header := make(map[int]interface{})
+	if err := cbor.Unmarshal(sign1.Protected, &header); err != nil {
+		return err
+	}
+
+	if alg, ok := header[COSE_HEADER_ALG].(int64); !ok || alg != COSE_ALG_ES256K {
+		return fmt.Errorf("unsupported cose algorithm: %v", header[COSE_HEADER_ALG])
+	}
+
+	if len(sign1.Signature) != 64 {
+		return fmt.Errorf("invalid signature length: %d", len(sign1.Signature))
+	}
+
+	toBeSigned, err := sigStructure(sign1.Protected, sign1.Payload)
+	if err != nil {
+		return err
+	}
+
+	digest := sha256.Sum256(toBeSigned)
+	for v := byte(0); v < 2; v++ {
+		signature := append(append([]byte{}, sign1.Signature...), v)
+		pubKey, err := crypto.SigToPub(digest[:], signature)
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(crypto.PubkeyToAddress(*pubKey).String(), publicAddress) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("public address and signer address does not match")
+}
+
+func coseKey(key *ecdsa.PublicKey) (map[int]interface{}, error) {
+	var crv int
+	switch key.Curve {
+	case elliptic.P256():
+		crv = COSE_CRV_P256
+	case crypto.S256():
+		crv = COSE_CRV_SECP256
+	default:
+		return nil, fmt.Errorf("unsupported device key curve")
+	}
+
+	size := (key.Curve.Params().BitSize + 7) / 8
+	x := make([]byte, size)
+	y := make([]byte, size)
+	key.X.FillBytes(x)
+	key.Y.FillBytes(y)
+
+	return map[int]interface{}{
+		1:  COSE_KTY_EC2,
+		-1: crv,
+		-2: x,
+		-3: y,
+	}, nil
+}
@@ -0,0 +1,144 @@
+package mdoc
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/fxamacker/cbor/v2"
+)
+
+// ExportOptions - Options of ExportWithOptions
+type ExportOptions struct {
+	// Clock - Time source of validityInfo.signed, time.Now when nil
+	Clock func() time.Time
+}
+
+// Export - Export Anima signed document as ISO 18013-5 IssuerSigned structure
+func Export(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, deviceKey *ecdsa.PublicKey) (*IssuerSigned, error) {
+	return ExportWithOptions(anima, issuer, request, deviceKey, nil)
+}
+
+// ExportWithOptions - Export Anima signed document as ISO 18013-5 IssuerSigned structure with export options
+func ExportWithOptions(anima *models.Protocol, issuer *protocol.AnimaIssuer, request *protocol.IssueRequest, deviceKey *ecdsa.PublicKey, opts *ExportOptions) (*IssuerSigned, error) {
+	now := time.Now
+	if opts != nil && opts.Clock != nil {
+		now = opts.Clock
+	}
+
+	if request.Document == nil || len(request.Document.Attributes) == 0 {
+		return nil, fmt.Errorf("document has no attributes")
+	}
+
+	if deviceKey == nil {
+		return nil, fmt.Errorf("device key is required")
+	}
+
+	if request.Document.ExpiresAt <= 0 {
+		return nil, fmt.Errorf("document has no expiration")
+	}
+
+	key, err := coseKey(deviceKey)
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for name := range request.Document.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := []cbor.Tag{}
+	digests := make(map[uint64][]byte)
+	var issuedAt int64
+	for i, name := range names {
+		attribute := request.Document.Attributes[name]
+		credential := request.Attributes[name]
+		if attribute.Content == nil || attribute.Credential == nil || credential == nil || credential.Credential == nil || credential.Credential.Content == nil {
+			return nil, fmt.Errorf("attribute %s is not signed", name)
+		}
+		// The document is valid from the earliest credential issuance
+		if issuedAt == 0 || credential.Credential.Content.IssuedAt < issuedAt {
+			issuedAt = credential.Credential.Content.IssuedAt
+		}
+
+		random := make([]byte, 16)
+		if _, err := rand.Read(random); err != nil {
+			return nil, err
+		}
+
+		item, err := encMode.Marshal(IssuerSignedItem{
+			DigestID:          uint64(i),
+			Random:            random,
+			ElementIdentifier: name,
+			ElementValue:      attribute.Content.Value,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tag := cbor.Tag{Number: TAG_ENCODED_CBOR, Content: item}
+		digest, err := digestItem(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		digests[uint64(i)] = digest
+		items = append(items, tag)
+	}
+
+	if issuedAt <= 0 {
+		return nil, fmt.Errorf("document has no issuance time")
+	}
+
+	if issuedAt >= request.Document.ExpiresAt {
+		return nil, fmt.Errorf("document expires before it is issued")
+	}
+
+	mso, err := encMode.Marshal(MobileSecurityObject{
+		Version:         MSO_VERSION,
+		DigestAlgorithm: DIGEST_ALGORITHM,
+		ValueDigests:    map[string]map[uint64][]byte{NAMESPACE: digests},
+		DeviceKeyInfo:   DeviceKeyInfo{DeviceKey: key},
+		DocType:         request.Document.Specs,
+		ValidityInfo: ValidityInfo{
+			Signed:     now().UTC(),
+			ValidFrom:  time.Unix(issuedAt, 0).UTC(),
+			ValidUntil: time.Unix(request.Document.ExpiresAt, 0).UTC(),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := encMode.Marshal(cbor.Tag{Number: TAG_ENCODED_CBOR, Content: mso})
+	if err != nil {
+		return nil, err
+	}
+
+	issuerAuth, err := signCose(anima, issuer, payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IssuerSigned{
+		NameSpaces: map[string][]cbor.Tag{NAMESPACE: items},
+		IssuerAuth: *issuerAuth,
+	}, nil
+}
+
+func digestItem(tag cbor.Tag) ([]byte, error) {
+	b, err := encMode.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(b)
+	return digest[:], nil
+}
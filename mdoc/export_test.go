@@ -0,0 +1,159 @@
+package mdoc_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+	"time"
+
+	anima "github.com/anima-protocol/anima-go"
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/mdoc"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+// signedPassport - Passport IssueRequest signed by issuerKey, without calling the protocol
+func signedPassport(t *testing.T, issuerKey *ecdsa.PrivateKey) (*models.Protocol, *protocol.AnimaIssuer, *protocol.IssueRequest) {
+	t.Helper()
+	ownerKey, ownerAddress := newKey(t)
+	issuerAddress := crypto.PubkeyToAddress(issuerKey.PublicKey).Hex()
+
+	authorization, err := core.CreateIssuingAuthorization(&models.IssuingAuthorization{
+		Specs:       models.DOCUMENT_SPECS_PASSPORT,
+		RequestedAt: uint64(time.Now().Unix()),
+		Fields:      map[string]string{},
+		Attributes: map[string]bool{
+			"firstname": true, "lastname": true, "birth_date": true, "nationality": true,
+			"document_number": true, "expiration_date": true, "issuing_country": true,
+		},
+		Owner:  models.AnimaOwner{ID: "owner", PublicAddress: ownerAddress, Chain: models.CHAIN_ETH},
+		Issuer: models.AnimaIssuer{ID: "issuer", PublicAddress: issuerAddress, Chain: models.CHAIN_ETH},
+	}, evm.PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := anima.NewIssuance().
+		Document(models.DOCUMENT_SPECS_PASSPORT).
+		ExpiresAt(time.Now().AddDate(1, 0, 0)).
+		Owner(authorization).
+		Attribute("firstname", "Jane").
+		Attribute("lastname", "Doe").
+		Attribute("document_number", "X1234567").
+		Attribute("birth_date", time.Now().AddDate(-30, 0, 0)).
+		Attribute("expiration_date", time.Now().AddDate(3, 0, 0)).
+		Attribute("nationality", "FR").
+		Attribute("issuing_country", "FR").
+		Proof(models.PROOF_SPECS_MANUAL_REVIEW, map[string]interface{}{"reviewer": "r", "reviewed_at": time.Now().Unix(), "decision": "approved"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &models.Protocol{Network: models.LOCALNET, Chain: models.CHAIN_ETH, SigningFunc: evm.PrivateKeySigningFunc(issuerKey)}
+	issuer := &protocol.AnimaIssuer{Id: "issuer", PublicAddress: issuerAddress, Chain: models.CHAIN_ETH}
+
+	result, err := anima.IssueWithOptions(config, issuer, request, &core.IssuingOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config, issuer, result.Request
+}
+
+func TestExportVerifyRoundTrip(t *testing.T) {
+	issuerKey, issuerAddress := newKey(t)
+	deviceKey, _ := newKey(t)
+	config, issuer, request := signedPassport(t, issuerKey)
+
+	signedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	exported, err := mdoc.ExportWithOptions(config, issuer, request, &deviceKey.PublicKey, &mdoc.ExportOptions{
+		Clock: func() time.Time { return signedAt },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, err := exported.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsed, err := mdoc.Parse(encoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mso, err := mdoc.Verify(parsed, models.DOCUMENT_SPECS_PASSPORT, issuerAddress)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if mso.DocType != models.DOCUMENT_SPECS_PASSPORT {
+		t.Errorf("doc type = %s", mso.DocType)
+	}
+
+	if !mso.ValidityInfo.Signed.Equal(signedAt) {
+		t.Errorf("signed = %s, want %s", mso.ValidityInfo.Signed, signedAt)
+	}
+
+	if !mso.ValidityInfo.ValidUntil.Equal(time.Unix(request.Document.ExpiresAt, 0)) {
+		t.Errorf("valid until = %s", mso.ValidityInfo.ValidUntil)
+	}
+
+	attributes, err := parsed.Attributes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, attribute := range request.Document.Attributes {
+		if attributes[name] != attribute.Content.Value {
+			t.Errorf("%s = %v, want %v", name, attributes[name], attribute.Content.Value)
+		}
+	}
+
+	_, otherAddress := newKey(t)
+	if _, err := mdoc.Verify(parsed, models.DOCUMENT_SPECS_PASSPORT, otherAddress); err == nil {
+		t.Error("verified with another issuer address")
+	}
+
+	if _, err := mdoc.Verify(parsed, models.DOCUMENT_SPECS_NATIONAL_ID, issuerAddress); err == nil {
+		t.Error("verified a passport as another document type")
+	}
+}
+
+func TestExportRejectsMissingExpiration(t *testing.T) {
+	issuerKey, _ := newKey(t)
+	deviceKey, _ := newKey(t)
+	config, issuer, request := signedPassport(t, issuerKey)
+	request.Document.ExpiresAt = 0
+
+	if _, err := mdoc.Export(config, issuer, request, &deviceKey.PublicKey); err == nil {
+		t.Error("exported a document without expiration")
+	}
+}
+
+func TestExportRejectsMissingSigner(t *testing.T) {
+	issuerKey, _ := newKey(t)
+	deviceKey, _ := newKey(t)
+	config, issuer, request := signedPassport(t, issuerKey)
+
+	if _, err := mdoc.Export(&models.Protocol{Chain: models.CHAIN_ETH}, issuer, request, &deviceKey.PublicKey); !errors.Is(err, models.ErrInvalidConfig) {
+		t.Errorf("err = %v, want %v", err, models.ErrInvalidConfig)
+	}
+
+	if _, err := mdoc.Export(config, nil, request, &deviceKey.PublicKey); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Errorf("err = %v, want %v", err, models.ErrInvalidRequest)
+	}
+}
@@ -0,0 +1,126 @@
+package mdoc
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+const (
+	/* ISO 18013-5 */
+	MSO_VERSION      = "1.0"
+	DIGEST_ALGORITHM = "SHA-256"
+	TAG_ENCODED_CBOR = 24
+
+	/* ANIMA */
+	NAMESPACE = "io.anima.document.1"
+
+	/* COSE */
+	COSE_HEADER_ALG  = 1
+	COSE_HEADER_KID  = 4
+	COSE_ALG_ES256K  = -47
+	COSE_KTY_EC2     = 2
+	COSE_CRV_P256    = 1
+	COSE_CRV_SECP256 = 8
+)
+
+type IssuerSignedItem struct {
+	DigestID          uint64      `cbor:"digestID"`
+	Random            []byte      `cbor:"random"`
+	ElementIdentifier string      `cbor:"elementIdentifier"`
+	ElementValue      interface{} `cbor:"elementValue"`
+}
+
+type IssuerSigned struct {
+	NameSpaces map[string][]cbor.Tag `cbor:"nameSpaces"`
+	IssuerAuth CoseSign1             `cbor:"issuerAuth"`
+}
+
+type CoseSign1 struct {
+	_           struct{} `cbor:",toarray"`
+	Protected   []byte
+	Unprotected map[int]interface{}
+	Payload     []byte
+	Signature   []byte
+}
+
+type MobileSecurityObject struct {
+	Version         string                       `cbor:"version"`
+	DigestAlgorithm string                       `cbor:"digestAlgorithm"`
+	ValueDigests    map[string]map[uint64][]byte `cbor:"valueDigests"`
+	DeviceKeyInfo   DeviceKeyInfo                `cbor:"deviceKeyInfo"`
+	DocType         string                       `cbor:"docType"`
+	ValidityInfo    ValidityInfo                 `cbor:"validityInfo"`
+}
+
+type DeviceKeyInfo struct {
+	DeviceKey map[int]interface{} `cbor:"deviceKey"`
+}
+
+type ValidityInfo struct {
+	Signed     time.Time `cbor:"signed"`
+	ValidFrom  time.Time `cbor:"validFrom"`
+	ValidUntil time.Time `cbor:"validUntil"`
+}
+
+var encMode, _ = func() (cbor.EncMode, error) {
+	opts := cbor.CoreDetEncOptions()
+	opts.Time = cbor.TimeRFC3339
+	opts.TimeTag = cbor.EncTagRequired
+	return opts.EncMode()
+}()
+
+// Marshal - Encode IssuerSigned structure to CBOR
+func (s *IssuerSigned) Marshal() ([]byte, error) {
+	return encMode.Marshal(s)
+}
+
+// Parse - Decode CBOR encoded IssuerSigned structure
+func Parse(data []byte) (*IssuerSigned, error) {
+	issuerSigned := IssuerSigned{}
+	if err := cbor.Unmarshal(data, &issuerSigned); err != nil {
+		return nil, err
+	}
+	return &issuerSigned, nil
+}
+
+// Items - Decode IssuerSignedItems of a namespace
+func (s *IssuerSigned) Items(namespace string) ([]*IssuerSignedItem, error) {
+	items := []*IssuerSignedItem{}
+	for _, tag := range s.NameSpaces[namespace] {
+		content, err := encodedCbor(tag)
+		if err != nil {
+			return nil, err
+		}
+
+		item := IssuerSignedItem{}
+		if err := cbor.Unmarshal(content, &item); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	return items, nil
+}
+
+// Attributes - Decode element values of Anima namespace by identifier
+func (s *IssuerSigned) Attributes() (map[string]interface{}, error) {
+	items, err := s.Items(NAMESPACE)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string]interface{})
+	for _, item := range items {
+		attributes[item.ElementIdentifier] = item.ElementValue
+	}
+	return attributes, nil
+}
+
+func encodedCbor(tag cbor.Tag) ([]byte, error) {
+	content, ok := tag.Content.([]byte)
+	if tag.Number != TAG_ENCODED_CBOR || !ok {
+		return nil, fmt.Errorf("invalid encoded cbor item")
+	}
+	return content, nil
+}
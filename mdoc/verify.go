@@ -0,0 +1,69 @@
+package mdoc
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+// Verify - Verify issuer signature, document type and item digests of an IssuerSigned structure
+func Verify(issuerSigned *IssuerSigned, docType string, publicAddress string) (*MobileSecurityObject, error) {
+	if err := verifyCose(&issuerSigned.IssuerAuth, publicAddress); err != nil {
+		return nil, err
+	}
+
+	payload := cbor.Tag{}
+	if err := cbor.Unmarshal(issuerSigned.IssuerAuth.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	content, err := encodedCbor(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	mso := MobileSecurityObject{}
+	if err := cbor.Unmarshal(content, &mso); err != nil {
+		return nil, err
+	}
+
+	if mso.DigestAlgorithm != DIGEST_ALGORITHM {
+		return nil, fmt.Errorf("unsupported digest algorithm: %s", mso.DigestAlgorithm)
+	}
+
+	if mso.DocType != docType {
+		return nil, fmt.Errorf("mobile security object is for document %s, not %s", mso.DocType, docType)
+	}
+
+	now := time.Now()
+	if now.Before(mso.ValidityInfo.ValidFrom) || now.After(mso.ValidityInfo.ValidUntil) {
+		return nil, fmt.Errorf("mobile security object is not valid at %s", now.UTC().Format(time.RFC3339))
+	}
+
+	for namespace, tags := range issuerSigned.NameSpaces {
+		items, err := issuerSigned.Items(namespace)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, item := range items {
+			expected, ok := mso.ValueDigests[namespace][item.DigestID]
+			if !ok {
+				return nil, fmt.Errorf("missing digest for %s/%s", namespace, item.ElementIdentifier)
+			}
+
+			digest, err := digestItem(tags[i])
+			if err != nil {
+				return nil, err
+			}
+
+			if !bytes.Equal(digest, expected) {
+				return nil, fmt.Errorf("invalid digest for %s/%s", namespace, item.ElementIdentifier)
+			}
+		}
+	}
+
+	return &mso, nil
+}
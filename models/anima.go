@@ -1,10 +1,55 @@
 package models
 
+import (
+	"crypto/tls"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
 type Protocol struct {
 	Network     string                       `json:"network"`
 	Chain       string                       `json:"chain"`
 	SigningFunc func([]byte) (string, error) `json:"signing_func"`
 	Secure      bool                         `json:"secure"`
+	// SpecsVersion - Canonicalization of hashed payloads, legacy 1.0.0 when empty
+	SpecsVersion string `json:"specs_version,omitempty"`
+	// EncryptAttributes - Encrypt attribute values and proof to the owner public encryption key
+	EncryptAttributes bool `json:"encrypt_attributes,omitempty"`
+	// AuthorizationMaxAge - Freshness window of issuing authorizations, DEFAULT_AUTHORIZATION_MAX_AGE when zero
+	AuthorizationMaxAge time.Duration `json:"authorization_max_age,omitempty"`
+	// TLS - Transport settings of secure connections, certificates are not verified when nil
+	TLS *tls.Config `json:"-"`
+	// Timeout - Deadline of each Anima Protocol call attempt, none when zero
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// Retry - Retry policy of Anima Protocol calls, DefaultRetryPolicy when nil
+	Retry *RetryPolicy `json:"retry,omitempty"`
+	// DialOptions - Extra gRPC dial options, e.g. an in-process bufconn dialer
+	DialOptions []grpc.DialOption `json:"-"`
+}
+
+// GetSpecsVersion - Specs version used for hashing, legacy 1.0.0 when unset
+func (p *Protocol) GetSpecsVersion() string {
+	if p.SpecsVersion == "" {
+		return SPECS_VERSION_LEGACY
+	}
+	return p.SpecsVersion
+}
+
+// GetAuthorizationMaxAge - Freshness window of issuing authorizations
+func (p *Protocol) GetAuthorizationMaxAge() time.Duration {
+	if p.AuthorizationMaxAge == 0 {
+		return DEFAULT_AUTHORIZATION_MAX_AGE
+	}
+	return p.AuthorizationMaxAge
+}
+
+// GetRetryPolicy - Retry policy of Anima Protocol calls
+func (p *Protocol) GetRetryPolicy() *RetryPolicy {
+	if p.Retry == nil {
+		return DefaultRetryPolicy()
+	}
+	return p.Retry
 }
 
 type AnimaOwner struct {
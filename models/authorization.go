@@ -8,3 +8,12 @@ type IssuingAuthorization struct {
 	Owner       AnimaOwner        `json:"owner"`
 	Issuer      AnimaIssuer       `json:"issuer"`
 }
+
+// SharingAuthorization - Owner consent to share attributes with a verifier
+type SharingAuthorization struct {
+	Specs       string        `json:"specs"`
+	RequestedAt uint64        `json:"requested_at"`
+	Attributes  []string      `json:"attributes"`
+	Owner       AnimaOwner    `json:"owner"`
+	Verifier    AnimaVerifier `json:"verifier"`
+}
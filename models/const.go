@@ -1,13 +1,58 @@
 package models
 
+import "time"
+
 const (
 	/* PROTOCOL */
 	PROTOCOL_NAME    = "anima"
 	PROTOCOL_VERSION = "1.0"
 
+	/* SPECS */
+	SPECS_VERSION_LEGACY = "1.0.0"
+	SPECS_VERSION_JCS    = "1.1.0"
+
+	/* ATTRIBUTE TYPES */
+	ATTRIBUTE_TYPE_STRING  = "string"
+	ATTRIBUTE_TYPE_INTEGER = "integer"
+	ATTRIBUTE_TYPE_BOOLEAN = "boolean"
+	ATTRIBUTE_TYPE_DATE    = "date"
+	ATTRIBUTE_TYPE_COUNTRY = "country"
+	ATTRIBUTE_TYPE_EMAIL   = "email"
+	ATTRIBUTE_TYPE_PHONE   = "phone"
+	ATTRIBUTE_TYPE_FILE    = "file"
+
+	/* DOCUMENT SPECS */
+	DOCUMENT_SPECS_PASSPORT             = "anima:specs:document/passport@1.0.0"
+	DOCUMENT_SPECS_NATIONAL_ID          = "anima:specs:document/national_id@1.0.0"
+	DOCUMENT_SPECS_DRIVER_LICENSE       = "anima:specs:document/driver_license@1.0.0"
+	DOCUMENT_SPECS_PROOF_OF_ADDRESS     = "anima:specs:document/proof_of_address@1.0.0"
+	DOCUMENT_SPECS_COMPANY_REGISTRATION = "anima:specs:document/company_registration@1.0.0"
+
+	/* PROOF SPECS */
+	PROOF_SPECS_DOCUMENT_SCAN = "anima:specs:proof/document_scan@1.0.0"
+	PROOF_SPECS_LIVENESS      = "anima:specs:proof/liveness@1.0.0"
+	PROOF_SPECS_MANUAL_REVIEW = "anima:specs:proof/manual_review@1.0.0"
+	PROOF_SPECS_KYC_REPORT    = "anima:specs:proof/kyc_report@1.0.0"
+
+	/* AUTHORIZATION */
+	DEFAULT_AUTHORIZATION_MAX_AGE = 24 * time.Hour
+	AUTHORIZATION_CLOCK_SKEW      = 5 * time.Minute
+
+	/* PROTOCOL REQUESTS */
+	PROTOCOL_REQUEST_MAX_AGE    = 5 * time.Minute
+	PROTOCOL_REQUEST_NONCE_SIZE = 16
+
+	/* RETRY */
+	DEFAULT_RETRY_MAX_ATTEMPTS    = 3
+	DEFAULT_RETRY_INITIAL_BACKOFF = 200 * time.Millisecond
+	DEFAULT_RETRY_MAX_BACKOFF     = 5 * time.Second
+	DEFAULT_RETRY_MULTIPLIER      = 2.0
+	DEFAULT_RETRY_JITTER          = 0.2
+
 	/* NETWORK */
-	MAINNET = "protocol.anima.io:443"
-	TESTNET = "protocol-tesnet.anima.io:443"
+	MAINNET  = "protocol.anima.io:443"
+	TESTNET  = "protocol-testnet.anima.io:443"
+	LOCALNET = "localhost:50051"
 
 	/* CHAIN */
 	CHAIN_ETH    = "ETH"
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrUnsupportedChain     = errors.New("unsupported chain")
+	ErrBadSignature         = errors.New("bad signature")
+	ErrAuthorizationExpired = errors.New("authorization expired")
+	ErrNetworkUnavailable   = errors.New("network unavailable")
+	ErrRejected             = errors.New("rejected by protocol")
+	ErrInvalidConfig        = errors.New("invalid config")
+	ErrReplayedRequest      = errors.New("replayed request")
+)
+
+// errorKinds - Typed errors reported instead of messages, which may carry attribute values
+var errorKinds = []error{
+	ErrInvalidRequest,
+	ErrUnsupportedChain,
+	ErrBadSignature,
+	ErrAuthorizationExpired,
+	ErrReplayedRequest,
+	ErrNetworkUnavailable,
+	ErrRejected,
+	ErrInvalidConfig,
+}
+
+// ErrorKind - Kind of err safe to log or export to traces, never its message
+func ErrorKind(err error) string {
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind) {
+			return kind.Error()
+		}
+	}
+
+	if s, ok := status.FromError(err); ok {
+		return s.Code().String()
+	}
+	return "error"
+}
+
+// Error - SDK error of a given kind, matching its sentinel with errors.Is
+type Error struct {
+	Kind    error
+	Message string
+	Cause   error
+}
+
+func NewError(kind error, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func WrapError(kind error, cause error, format string, args ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...), Cause: cause}
+}
+
+func (e *Error) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Cause)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+func (e *Error) Is(target error) bool {
+	return target == e.Kind
+}
+
+// ProtocolError - Error status returned by the Anima Protocol service
+type ProtocolError struct {
+	Code    codes.Code
+	Message string
+	Details []interface{}
+	Cause   error
+}
+
+func (e *ProtocolError) Error() string {
+	return fmt.Sprintf("anima protocol: %s: %s", e.Code, e.Message)
+}
+
+func (e *ProtocolError) Unwrap() error {
+	return e.Cause
+}
+
+func (e *ProtocolError) Is(target error) bool {
+	switch e.Code {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled:
+		return target == ErrNetworkUnavailable
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return target == ErrRejected || target == ErrInvalidRequest
+	case codes.Unauthenticated:
+		return target == ErrRejected || target == ErrBadSignature
+	}
+	return target == ErrRejected
+}
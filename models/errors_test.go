@@ -0,0 +1,98 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorMatchesKind(t *testing.T) {
+	err := error(NewError(ErrUnsupportedChain, "chain unavailable: %s", "SOL"))
+	if !errors.Is(err, ErrUnsupportedChain) || errors.Is(err, ErrInvalidRequest) {
+		t.Errorf("%v matches another kind", err)
+	}
+
+	if err.Error() != "chain unavailable: SOL" {
+		t.Errorf("message = %s", err.Error())
+	}
+
+	var typed *Error
+	if !errors.As(err, &typed) || typed.Kind != ErrUnsupportedChain {
+		t.Errorf("errors.As = %+v", typed)
+	}
+}
+
+func TestWrapErrorKeepsCause(t *testing.T) {
+	err := error(WrapError(ErrNetworkUnavailable, io.ErrUnexpectedEOF, "could not connect to %s", "anima"))
+	if !errors.Is(err, ErrNetworkUnavailable) || !errors.Is(err, io.ErrUnexpectedEOF) {
+		t.Errorf("%v does not match its kind and cause", err)
+	}
+
+	if err.Error() != "could not connect to anima: unexpected EOF" {
+		t.Errorf("message = %s", err.Error())
+	}
+}
+
+func TestProtocolErrorKinds(t *testing.T) {
+	kinds := []error{ErrNetworkUnavailable, ErrRejected, ErrInvalidRequest, ErrBadSignature}
+	for _, test := range []struct {
+		code codes.Code
+		is   []error
+	}{
+		{codes.Unavailable, []error{ErrNetworkUnavailable}},
+		{codes.DeadlineExceeded, []error{ErrNetworkUnavailable}},
+		{codes.Canceled, []error{ErrNetworkUnavailable}},
+		{codes.InvalidArgument, []error{ErrRejected, ErrInvalidRequest}},
+		{codes.FailedPrecondition, []error{ErrRejected, ErrInvalidRequest}},
+		{codes.OutOfRange, []error{ErrRejected, ErrInvalidRequest}},
+		{codes.Unauthenticated, []error{ErrRejected, ErrBadSignature}},
+		{codes.PermissionDenied, []error{ErrRejected}},
+		{codes.Internal, []error{ErrRejected}},
+	} {
+		t.Run(test.code.String(), func(t *testing.T) {
+			err := &ProtocolError{Code: test.code, Message: "message"}
+			for _, kind := range kinds {
+				want := false
+				for _, is := range test.is {
+					want = want || is == kind
+				}
+
+				if errors.Is(err, kind) != want {
+					t.Errorf("errors.Is(%v) = %t, want %t", kind, !want, want)
+				}
+			}
+		})
+	}
+}
+
+func TestProtocolErrorUnwrapsCause(t *testing.T) {
+	err := error(&ProtocolError{Code: codes.Internal, Message: "boom", Cause: io.EOF})
+	if !errors.Is(err, io.EOF) {
+		t.Error("cause is not unwrapped")
+	}
+
+	if err.Error() != "anima protocol: Internal: boom" {
+		t.Errorf("message = %s", err.Error())
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	for _, test := range []struct {
+		err  error
+		kind string
+	}{
+		{NewError(ErrBadSignature, "signer 0x1234 does not match"), "bad signature"},
+		{fmt.Errorf("issue: %w", NewError(ErrReplayedRequest, "nonce reused")), "replayed request"},
+		{&ProtocolError{Code: codes.Unavailable, Message: "connection refused"}, "network unavailable"},
+		{status.Error(codes.PermissionDenied, "owner satoshi@example.com"), "PermissionDenied"},
+		{errors.New("firstname Satoshi"), "error"},
+	} {
+		if kind := ErrorKind(test.err); kind != test.kind {
+			t.Errorf("ErrorKind(%v) = %s, want %s", test.err, kind, test.kind)
+		}
+	}
+}
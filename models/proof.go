@@ -0,0 +1,36 @@
+package models
+
+type DocumentScanProof struct {
+	Provider       string   `json:"provider"`
+	DocumentType   string   `json:"document_type"`
+	IssuingCountry string   `json:"issuing_country"`
+	Pages          []string `json:"pages"`
+	MrzValid       bool     `json:"mrz_valid"`
+	ChipVerified   bool     `json:"chip_verified,omitempty"`
+	ScannedAt      int64    `json:"scanned_at"`
+}
+
+type LivenessCheckProof struct {
+	Provider       string  `json:"provider"`
+	Method         string  `json:"method"`
+	Score          float64 `json:"score"`
+	FaceMatchScore float64 `json:"face_match_score,omitempty"`
+	Passed         bool    `json:"passed"`
+	CheckedAt      int64   `json:"checked_at"`
+}
+
+type ManualReviewProof struct {
+	Reviewer   string `json:"reviewer"`
+	Decision   string `json:"decision"`
+	Notes      string `json:"notes,omitempty"`
+	ReviewedAt int64  `json:"reviewed_at"`
+}
+
+type KYCReportProof struct {
+	Provider    string   `json:"provider"`
+	ReportID    string   `json:"report_id"`
+	Level       string   `json:"level"`
+	Result      string   `json:"result"`
+	Checks      []string `json:"checks"`
+	CompletedAt int64    `json:"completed_at"`
+}
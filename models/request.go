@@ -0,0 +1,9 @@
+package models
+
+// ProtocolRequestClaims - Replay protection fields covered by a protocol request signature
+type ProtocolRequestClaims struct {
+	Method    string `json:"method"`
+	Network   string `json:"network"`
+	Timestamp int64  `json:"timestamp"`
+	Nonce     string `json:"nonce"`
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+// RetryPolicy - Exponential backoff with jitter for transient Anima Protocol errors
+type RetryPolicy struct {
+	// MaxAttempts - Attempts including the first call, 1 disables retries
+	MaxAttempts    int           `json:"max_attempts"`
+	InitialBackoff time.Duration `json:"initial_backoff"`
+	MaxBackoff     time.Duration `json:"max_backoff"`
+	Multiplier     float64       `json:"multiplier"`
+	// Jitter - Fraction of each backoff randomly removed, between 0 and 1
+	Jitter         float64      `json:"jitter"`
+	RetryableCodes []codes.Code `json:"retryable_codes"`
+}
+
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:    DEFAULT_RETRY_MAX_ATTEMPTS,
+		InitialBackoff: DEFAULT_RETRY_INITIAL_BACKOFF,
+		MaxBackoff:     DEFAULT_RETRY_MAX_BACKOFF,
+		Multiplier:     DEFAULT_RETRY_MULTIPLIER,
+		Jitter:         DEFAULT_RETRY_JITTER,
+		RetryableCodes: []codes.Code{codes.Unavailable, codes.ResourceExhausted},
+	}
+}
+
+// Retryable - Whether a call failing with code may be attempted again
+func (r *RetryPolicy) Retryable(code codes.Code) bool {
+	for _, retryable := range r.RetryableCodes {
+		if code == retryable {
+			return true
+		}
+	}
+	return false
+}
+
+// Backoff - Delay before the given retry, starting at 1
+func (r *RetryPolicy) Backoff(retry int) time.Duration {
+	multiplier := r.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(r.InitialBackoff) * math.Pow(multiplier, float64(retry-1))
+	if r.MaxBackoff > 0 && backoff > float64(r.MaxBackoff) {
+		backoff = float64(r.MaxBackoff)
+	}
+
+	if r.Jitter > 0 {
+		backoff -= backoff * math.Min(r.Jitter, 1) * rand.Float64()
+	}
+
+	return time.Duration(backoff)
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+)
+
+func TestBackoff(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+	for retry, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		if backoff := policy.Backoff(retry); backoff != want {
+			t.Errorf("retry %d: backoff = %s, want %s", retry, backoff, want)
+		}
+	}
+
+	// A multiplier below 1 would shrink the backoff, it keeps it constant instead
+	policy.Multiplier = 0.5
+	if backoff := policy.Backoff(3); backoff != 100*time.Millisecond {
+		t.Errorf("backoff = %s with multiplier below 1", backoff)
+	}
+}
+
+func TestBackoffJitter(t *testing.T) {
+	policy := &RetryPolicy{InitialBackoff: 100 * time.Millisecond, Multiplier: 1, Jitter: 0.25}
+	for i := 0; i < 100; i++ {
+		if backoff := policy.Backoff(1); backoff < 75*time.Millisecond || backoff > 100*time.Millisecond {
+			t.Fatalf("backoff = %s, want within 25%% below 100ms", backoff)
+		}
+	}
+
+	// Jitter above 1 never gives a negative backoff
+	policy.Jitter = 3
+	for i := 0; i < 100; i++ {
+		if backoff := policy.Backoff(1); backoff < 0 {
+			t.Fatalf("backoff = %s", backoff)
+		}
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	policy := DefaultRetryPolicy()
+	for code, want := range map[codes.Code]bool{
+		codes.Unavailable:       true,
+		codes.ResourceExhausted: true,
+		codes.DeadlineExceeded:  false,
+		codes.InvalidArgument:   false,
+		codes.Unauthenticated:   false,
+		codes.Internal:          false,
+	} {
+		if policy.Retryable(code) != want {
+			t.Errorf("Retryable(%s) = %t, want %t", code, !want, want)
+		}
+	}
+
+	if (&Protocol{}).GetRetryPolicy().MaxAttempts != DEFAULT_RETRY_MAX_ATTEMPTS {
+		t.Error("protocol without policy does not use the default")
+	}
+}
@@ -0,0 +1,246 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/logger"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/validators"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Node - Reference in-memory Anima Protocol node for local development and tests
+type Node struct {
+	protocol.UnimplementedAnimaServer
+
+	// Protocol - Chain, specs version and signing function of the node, used to sign VerifyResponse
+	Protocol *models.Protocol
+	// Identity - Node identity returned in verifications
+	Identity *protocol.AnimaProtocol
+	// Clock - Time source of authorization freshness and credential expiry, time.Now when nil
+	Clock func() time.Time
+
+	store *store
+}
+
+// NewNode - Create a node, credentials and verifiers are persisted to dir when not empty
+func NewNode(anima *models.Protocol, identity *protocol.AnimaProtocol, dir string) (*Node, error) {
+	if anima == nil || anima.SigningFunc == nil {
+		return nil, models.NewError(models.ErrInvalidConfig, "node requires a signing function")
+	}
+
+	if identity == nil || identity.PublicAddress == "" {
+		return nil, models.NewError(models.ErrInvalidConfig, "node requires an identity")
+	}
+
+	s, err := openStore(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Node{Protocol: anima, Identity: identity, store: s}, nil
+}
+
+func (n *Node) now() time.Time {
+	if n.Clock != nil {
+		return n.Clock()
+	}
+	return time.Now()
+}
+
+// Issue - Verify issuer signatures and store the attribute credentials of a document
+func (n *Node) Issue(ctx context.Context, req *protocol.IssueRequest) (*protocol.Empty, error) {
+	caller, ok := protocol.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated request")
+	}
+
+	if err := validators.ValidateIssueRequest(req); err != nil {
+		return nil, toStatus(err)
+	}
+
+	authorization, err := core.GetIssuingAuthorization(req)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if !strings.EqualFold(authorization.Issuer.PublicAddress, caller.PublicAddress) {
+		return nil, status.Error(codes.PermissionDenied, "issuer is not authorized by the owner")
+	}
+
+	credentials := make(map[string]*protocol.AnimaCredentialAttribute, len(req.Attributes))
+	for name, attribute := range req.Attributes {
+		if attribute.Credential == nil || attribute.Credential.Content == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "attributes.%s.credential: is required", name)
+		}
+
+		if err := core.VerifyCredential(attribute.Credential); err != nil {
+			return nil, toStatus(err)
+		}
+
+		content := attribute.Credential.Content
+		if content.Owner == nil || content.Document == nil || content.Proof == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "attributes.%s.credential.content: is incomplete", name)
+		}
+
+		if !strings.EqualFold(content.Issuer.PublicAddress, caller.PublicAddress) {
+			return nil, status.Errorf(codes.PermissionDenied, "attributes.%s: credential is not signed by the caller", name)
+		}
+
+		if !strings.EqualFold(content.Owner.PublicAddress, authorization.Owner.PublicAddress) {
+			return nil, status.Errorf(codes.InvalidArgument, "attributes.%s: credential owner does not match the issuing authorization", name)
+		}
+
+		credentials[name] = &protocol.AnimaCredentialAttribute{
+			Owner:     content.Owner,
+			Issuer:    content.Issuer,
+			Document:  &protocol.AnimaDocument{Id: content.Document.Id, Specs: content.Document.Specs},
+			Attribute: content.Attribute,
+			Proof:     &protocol.AnimaProof{Id: content.Proof.Id, Specs: content.Proof.Specs},
+			IssuedAt:  content.IssuedAt,
+			ExpiresAt: content.ExpiresAt,
+		}
+	}
+
+	// Re-issuing the same document overwrites identical credentials, which keeps Issue idempotent
+	owner := strings.ToLower(authorization.Owner.PublicAddress)
+	err = n.store.update(func(state *state) {
+		if state.Credentials[owner] == nil {
+			state.Credentials[owner] = make(map[string]*protocol.AnimaCredentialAttribute)
+		}
+		for name, credential := range credentials {
+			state.Credentials[owner][name] = credential
+		}
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logger.Info("anima node issued credentials", logger.String("document_specs", req.Document.Specs), logger.Int("attributes", len(credentials)))
+	return &protocol.Empty{}, nil
+}
+
+// Verify - Check a sharing authorization and return the signed credentials it grants
+func (n *Node) Verify(ctx context.Context, req *protocol.VerifyRequest) (*protocol.VerifyResponse, error) {
+	caller, ok := protocol.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated request")
+	}
+
+	if err := validators.ValidateVerifyRequest(req); err != nil {
+		return nil, toStatus(err)
+	}
+
+	authorization, err := core.GetSharingAuthorization(req.Authorization)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+
+	if !strings.EqualFold(authorization.Verifier.PublicAddress, caller.PublicAddress) {
+		return nil, status.Error(codes.PermissionDenied, "sharing authorization is for another verifier")
+	}
+
+	requestedAt := time.Unix(int64(authorization.RequestedAt), 0)
+	if n.now().Sub(requestedAt) > models.DEFAULT_AUTHORIZATION_MAX_AGE || requestedAt.After(n.now().Add(models.AUTHORIZATION_CLOCK_SKEW)) {
+		return nil, toStatus(models.NewError(models.ErrAuthorizationExpired, "sharing authorization is not fresh"))
+	}
+
+	var verifier *protocol.RegisterVerifierRequest
+	credentials := make(map[string]*protocol.AnimaCredentialAttribute, len(authorization.Attributes))
+	n.store.read(func(state *state) {
+		verifier = state.Verifiers[strings.ToLower(caller.PublicAddress)]
+		for _, name := range authorization.Attributes {
+			if credential, ok := state.Credentials[strings.ToLower(authorization.Owner.PublicAddress)][name]; ok {
+				credentials[name] = credential
+			}
+		}
+	})
+
+	if verifier == nil {
+		return nil, status.Error(codes.PermissionDenied, "verifier is not registered")
+	}
+
+	var issuer *protocol.AnimaIssuer
+	for _, name := range authorization.Attributes {
+		credential, ok := credentials[name]
+		if !ok || credential.ExpiresAt <= n.now().Unix() {
+			return nil, status.Errorf(codes.NotFound, "no valid credential for attribute %s", name)
+		}
+
+		if issuer == nil {
+			issuer = credential.Issuer
+		} else if !strings.EqualFold(issuer.PublicAddress, credential.Issuer.PublicAddress) {
+			issuer = &protocol.AnimaIssuer{}
+		}
+	}
+
+	content := &protocol.VerificationContent{
+		Verifier: &protocol.AnimaVerifier{
+			Id:            verifier.Id,
+			PublicAddress: verifier.PublicAddress,
+			Chain:         verifier.Chain,
+		},
+		Owner: &protocol.AnimaOwner{
+			Id:            authorization.Owner.ID,
+			PublicAddress: authorization.Owner.PublicAddress,
+			Chain:         authorization.Owner.Chain,
+			Wallet:        authorization.Owner.Wallet,
+		},
+		Issuer:        issuer,
+		Protocol:      n.Identity,
+		Credentials:   credentials,
+		Authorization: req.Authorization,
+	}
+
+	signature, err := evm.SignVerification(n.Protocol, content, n.Protocol.SigningFunc)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protocol.VerifyResponse{Content: content, Signature: "0x" + strings.TrimPrefix(signature, "0x")}, nil
+}
+
+// RegisterVerifier - Register the calling verifier
+func (n *Node) RegisterVerifier(ctx context.Context, req *protocol.RegisterVerifierRequest) (*protocol.RegisterVerifierResponse, error) {
+	caller, ok := protocol.CallerFromContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated request")
+	}
+
+	if err := validators.ValidateRegisterVerifierRequest(req); err != nil {
+		return nil, toStatus(err)
+	}
+
+	if !strings.EqualFold(req.PublicAddress, caller.PublicAddress) {
+		return nil, status.Error(codes.PermissionDenied, "verifiers can only register themselves")
+	}
+
+	err := n.store.update(func(state *state) {
+		state.Verifiers[strings.ToLower(req.PublicAddress)] = req
+	})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &protocol.RegisterVerifierResponse{Id: req.Id}, nil
+}
+
+// toStatus - gRPC status of an SDK error, mirroring how models.ProtocolError classifies codes
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, models.ErrBadSignature):
+		return status.Error(codes.Unauthenticated, err.Error())
+	case errors.Is(err, models.ErrAuthorizationExpired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, models.ErrInvalidRequest), errors.Is(err, models.ErrUnsupportedChain):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
@@ -0,0 +1,214 @@
+package node_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	anima "github.com/anima-protocol/anima-go"
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/node"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc/codes"
+)
+
+type account struct {
+	key     *ecdsa.PrivateKey
+	address string
+}
+
+func newAccount(t *testing.T) *account {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &account{key: key, address: crypto.PubkeyToAddress(key.PublicKey).Hex()}
+}
+
+func (a *account) protocol(network string) *models.Protocol {
+	return &models.Protocol{Network: network, Chain: models.CHAIN_ETH, SigningFunc: evm.PrivateKeySigningFunc(a.key)}
+}
+
+func newNode(t *testing.T) (*node.Node, *account) {
+	t.Helper()
+	operator := newAccount(t)
+	n, err := node.NewNode(
+		&models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: models.SPECS_VERSION_JCS, SigningFunc: evm.PrivateKeySigningFunc(operator.key)},
+		&protocol.AnimaProtocol{Id: "anima:protocol:test", PublicAddress: operator.address, Chain: models.CHAIN_ETH},
+		t.TempDir(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n, operator
+}
+
+func passport(t *testing.T, owner *account, issuer *account) *protocol.IssueRequest {
+	t.Helper()
+	authorization, err := core.CreateIssuingAuthorization(&models.IssuingAuthorization{
+		Specs:       models.DOCUMENT_SPECS_PASSPORT,
+		RequestedAt: uint64(time.Now().Unix()),
+		Fields:      map[string]string{},
+		Attributes: map[string]bool{
+			"firstname": true, "lastname": true, "birth_date": true, "nationality": true,
+			"document_number": true, "expiration_date": true, "issuing_country": true,
+		},
+		Owner:  models.AnimaOwner{ID: "owner", PublicAddress: owner.address, Chain: models.CHAIN_ETH},
+		Issuer: models.AnimaIssuer{ID: "issuer", PublicAddress: issuer.address, Chain: models.CHAIN_ETH},
+	}, evm.PrivateKeySigningFunc(owner.key))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := anima.NewIssuance().
+		Document(models.DOCUMENT_SPECS_PASSPORT).
+		ExpiresAt(time.Now().AddDate(1, 0, 0)).
+		Owner(authorization).
+		Attribute("firstname", "Jane").
+		Attribute("lastname", "Doe").
+		Attribute("document_number", "X1234567").
+		Attribute("birth_date", time.Now().AddDate(-30, 0, 0)).
+		Attribute("expiration_date", time.Now().AddDate(3, 0, 0)).
+		Attribute("nationality", "FR").
+		Attribute("issuing_country", "FR").
+		Proof(models.PROOF_SPECS_MANUAL_REVIEW, map[string]interface{}{"reviewer": "r", "reviewed_at": time.Now().Unix(), "decision": "approved"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return request
+}
+
+func sharingAuthorization(t *testing.T, owner *account, verifier *account, attributes ...string) *protocol.SharingAuthorization {
+	t.Helper()
+	authorization, err := core.CreateSharingAuthorization(&models.SharingAuthorization{
+		RequestedAt: uint64(time.Now().Unix()),
+		Attributes:  attributes,
+		Owner:       models.AnimaOwner{ID: "owner", PublicAddress: owner.address, Chain: models.CHAIN_ETH},
+		Verifier:    models.AnimaVerifier{ID: "verifier", PublicAddress: verifier.address, Chain: models.CHAIN_ETH},
+	}, evm.PrivateKeySigningFunc(owner.key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return authorization
+}
+
+func protocolCode(err error) codes.Code {
+	protocolError := &models.ProtocolError{}
+	if errors.As(err, &protocolError) {
+		return protocolError.Code
+	}
+	return codes.Unknown
+}
+
+func TestIssueRegisterVerify(t *testing.T) {
+	n, operator := newNode(t)
+	dialOptions, stop := n.StartBufconn()
+	defer stop()
+	defer protocol.Close()
+
+	owner, issuer, verifier := newAccount(t), newAccount(t), newAccount(t)
+
+	issuerProtocol := issuer.protocol(node.BUFCONN_NETWORK)
+	issuerProtocol.DialOptions = dialOptions
+	err := anima.Issue(issuerProtocol, &protocol.AnimaIssuer{Id: "issuer", PublicAddress: issuer.address, Chain: models.CHAIN_ETH}, passport(t, owner, issuer))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifierProtocol := verifier.protocol(node.BUFCONN_NETWORK)
+	verifierProtocol.DialOptions = dialOptions
+	request := &protocol.VerifyRequest{Authorization: sharingAuthorization(t, owner, verifier, "firstname", "nationality")}
+
+	if _, err := anima.Verify(verifierProtocol, request); protocolCode(err) != codes.PermissionDenied {
+		t.Fatalf("verify before registration: %v", err)
+	}
+
+	_, err = anima.RegisterVerifier(verifierProtocol, &protocol.RegisterVerifierRequest{
+		Id:            "verifier",
+		PublicAddress: verifier.address,
+		Chain:         models.CHAIN_ETH,
+		Name:          "Verifier",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := anima.Verify(verifierProtocol, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	valid, err := evm.VerifyVerification(operator.address, models.SPECS_VERSION_JCS, res.Content, res.Signature)
+	if err != nil || !valid {
+		t.Fatalf("verification signature: %v %v", valid, err)
+	}
+
+	if len(res.Content.Credentials) != 2 || res.Content.Issuer.PublicAddress != issuer.address {
+		t.Errorf("verification content = %v", res.Content)
+	}
+
+	missing := &protocol.VerifyRequest{Authorization: sharingAuthorization(t, owner, verifier, "email")}
+	if _, err := anima.Verify(verifierProtocol, missing); protocolCode(err) != codes.NotFound {
+		t.Errorf("verify missing attribute: %v", err)
+	}
+
+	other := newAccount(t)
+	foreign := &protocol.VerifyRequest{Authorization: sharingAuthorization(t, owner, other, "firstname")}
+	if _, err := anima.Verify(verifierProtocol, foreign); protocolCode(err) != codes.PermissionDenied {
+		t.Errorf("verify authorization of another verifier: %v", err)
+	}
+}
+
+func TestIssueFromUnauthorizedCaller(t *testing.T) {
+	n, _ := newNode(t)
+	dialOptions, stop := n.StartBufconn()
+	defer stop()
+	defer protocol.Close()
+
+	owner, issuer, caller := newAccount(t), newAccount(t), newAccount(t)
+
+	signed, err := anima.IssueWithOptions(issuer.protocol(node.BUFCONN_NETWORK), &protocol.AnimaIssuer{Id: "issuer", PublicAddress: issuer.address, Chain: models.CHAIN_ETH}, passport(t, owner, issuer), &core.IssuingOptions{DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Relayed by another account than the issuer that signed the credentials
+	callerProtocol := caller.protocol(node.BUFCONN_NETWORK)
+	callerProtocol.DialOptions = dialOptions
+	if err := protocol.Issue(callerProtocol, signed.Request); protocolCode(err) != codes.PermissionDenied {
+		t.Errorf("issue relayed by another caller: %v", err)
+	}
+}
+
+func TestStartAcceptsRequestsSignedForListenAddress(t *testing.T) {
+	n, _ := newNode(t)
+	network, stop, err := n.Start("localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+	defer protocol.Close()
+
+	// Clients sign for the address they were configured with, not the resolved listener address
+	if host, _, _ := net.SplitHostPort(network); host != "localhost" {
+		t.Fatalf("network = %s, want the localhost host given to Start", network)
+	}
+
+	verifier := newAccount(t)
+	_, err = anima.RegisterVerifier(verifier.protocol(network), &protocol.RegisterVerifierRequest{
+		Id:            "verifier",
+		PublicAddress: verifier.address,
+		Chain:         models.CHAIN_ETH,
+		Name:          "Verifier",
+	})
+	if err != nil {
+		t.Fatalf("register verifier on %s: %v", network, err)
+	}
+}
@@ -0,0 +1,87 @@
+package node
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+const STATE_FILE = "anima-node.json"
+
+type state struct {
+	// Credentials - Attribute credentials by lowercase owner address, then attribute name
+	Credentials map[string]map[string]*protocol.AnimaCredentialAttribute `json:"credentials"`
+	// Verifiers - Registered verifiers by lowercase public address
+	Verifiers map[string]*protocol.RegisterVerifierRequest `json:"verifiers"`
+}
+
+// store - Node state kept in memory, mirrored to a JSON file when path is set
+type store struct {
+	mu    sync.RWMutex
+	path  string
+	state state
+}
+
+func openStore(dir string) (*store, error) {
+	s := &store{state: state{
+		Credentials: make(map[string]map[string]*protocol.AnimaCredentialAttribute),
+		Verifiers:   make(map[string]*protocol.RegisterVerifierRequest),
+	}}
+
+	if dir == "" {
+		return s, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+	s.path = filepath.Join(dir, STATE_FILE)
+
+	content, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &s.state); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *store) read(fn func(state *state)) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	fn(&s.state)
+}
+
+func (s *store) update(fn func(state *state)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fn(&s.state)
+	return s.persist()
+}
+
+func (s *store) persist() error {
+	if s.path == "" {
+		return nil
+	}
+
+	content, err := json.Marshal(s.state)
+	if err != nil {
+		return err
+	}
+
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, content, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
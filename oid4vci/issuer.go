@@ -0,0 +1,339 @@
+package oid4vci
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/validators"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	DEFAULT_OFFER_TTL = 10 * time.Minute
+	DEFAULT_TOKEN_TTL = 5 * time.Minute
+)
+
+type offer struct {
+	request   *protocol.IssueRequest
+	owner     string
+	expiresAt time.Time
+	cNonce    string
+}
+
+type Issuer struct {
+	URL      string
+	Protocol *models.Protocol
+	Issuer   *protocol.AnimaIssuer
+	// Publish - Also send issued credentials to Anima Protocol
+	Publish  bool
+	OfferTTL time.Duration
+	TokenTTL time.Duration
+
+	mu     sync.Mutex
+	offers map[string]*offer
+	tokens map[string]*offer
+	mux    *http.ServeMux
+}
+
+// NewIssuer - Create OID4VCI credential issuer served under url
+func NewIssuer(anima *models.Protocol, issuer *protocol.AnimaIssuer, url string) *Issuer {
+	i := &Issuer{
+		URL:      strings.TrimSuffix(url, "/"),
+		Protocol: anima,
+		Issuer:   issuer,
+		OfferTTL: DEFAULT_OFFER_TTL,
+		TokenTTL: DEFAULT_TOKEN_TTL,
+		offers:   make(map[string]*offer),
+		tokens:   make(map[string]*offer),
+		mux:      http.NewServeMux(),
+	}
+
+	i.mux.HandleFunc(METADATA_PATH, i.handleMetadata)
+	i.mux.HandleFunc(TOKEN_PATH, i.handleToken)
+	i.mux.HandleFunc(CREDENTIAL_PATH, i.handleCredential)
+	return i
+}
+
+func (i *Issuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	i.mux.ServeHTTP(w, r)
+}
+
+// Metadata - Credential issuer metadata
+func (i *Issuer) Metadata() *CredentialIssuerMetadata {
+	return &CredentialIssuerMetadata{
+		CredentialIssuer:   i.URL,
+		TokenEndpoint:      i.URL + TOKEN_PATH,
+		CredentialEndpoint: i.URL + CREDENTIAL_PATH,
+		CredentialConfigurationsSupported: map[string]CredentialConfiguration{
+			CREDENTIAL_CONFIGURATION_ID: {
+				Format: CREDENTIAL_FORMAT,
+				ProofTypesSupported: map[string]ProofTypeSupported{
+					PROOF_TYPE_JWT: {ProofSigningAlgValuesSupported: []string{PROOF_JWT_ALG}},
+				},
+			},
+		},
+	}
+}
+
+// Offer - Register an unsigned issue request and create a pre-authorized credential offer for its owner
+func (i *Issuer) Offer(request *protocol.IssueRequest) (*CredentialOffer, error) {
+	if err := validators.ValidateIssueRequest(request); err != nil {
+		return nil, err
+	}
+
+	issuingAuthorization, err := core.GetIssuingAuthorization(request)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.prune(time.Now())
+	i.offers[code] = &offer{
+		request:   proto.Clone(request).(*protocol.IssueRequest),
+		owner:     issuingAuthorization.Owner.PublicAddress,
+		expiresAt: time.Now().Add(i.OfferTTL),
+	}
+
+	return &CredentialOffer{
+		CredentialIssuer:           i.URL,
+		CredentialConfigurationIDs: []string{CREDENTIAL_CONFIGURATION_ID},
+		Grants: map[string]OfferGrants{
+			GRANT_TYPE_PRE_AUTHORIZED: {PreAuthorizedCode: code},
+		},
+	}, nil
+}
+
+// URI - Encode credential offer as openid-credential-offer:// URI
+func (o *CredentialOffer) URI() (string, error) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s://?%s", OFFER_URI_SCHEME, url.Values{"credential_offer": []string{string(b)}}.Encode()), nil
+}
+
+func (i *Issuer) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, i.Metadata())
+}
+
+func (i *Issuer) handleToken(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_request", err.Error())
+		return
+	}
+
+	if r.PostForm.Get("grant_type") != GRANT_TYPE_PRE_AUTHORIZED {
+		writeError(w, http.StatusBadRequest, "unsupported_grant_type", "only pre-authorized_code is supported")
+		return
+	}
+
+	code := r.PostForm.Get("pre-authorized_code")
+
+	i.mu.Lock()
+	i.prune(time.Now())
+	o, ok := i.offers[code]
+	delete(i.offers, code)
+	i.mu.Unlock()
+
+	if !ok || time.Now().After(o.expiresAt) {
+		writeError(w, http.StatusBadRequest, "invalid_grant", "unknown or expired pre-authorized_code")
+		return
+	}
+
+	token, err := randomString()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+
+	cNonce, err := randomString()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "server_error", err.Error())
+		return
+	}
+
+	i.mu.Lock()
+	o.cNonce = cNonce
+	o.expiresAt = time.Now().Add(i.TokenTTL)
+	i.tokens[token] = o
+	i.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &TokenResponse{
+		AccessToken:     token,
+		TokenType:       "Bearer",
+		ExpiresIn:       int64(i.TokenTTL.Seconds()),
+		CNonce:          cNonce,
+		CNonceExpiresIn: int64(i.TokenTTL.Seconds()),
+	})
+}
+
+func (i *Issuer) handleCredential(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	// The token is taken before verification so concurrent requests cannot both issue
+	i.mu.Lock()
+	i.prune(time.Now())
+	o, ok := i.tokens[token]
+	delete(i.tokens, token)
+	i.mu.Unlock()
+
+	if !ok || time.Now().After(o.expiresAt) {
+		writeError(w, http.StatusUnauthorized, "invalid_token", "unknown or expired access token")
+		return
+	}
+
+	request := CredentialRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&request); err != nil {
+		i.restore(token, o)
+		writeError(w, http.StatusBadRequest, "invalid_credential_request", err.Error())
+		return
+	}
+
+	if !supportedCredential(&request) {
+		i.restore(token, o)
+		writeError(w, http.StatusBadRequest, "unsupported_credential_format", "unsupported credential configuration")
+		return
+	}
+
+	if request.Proof == nil || request.Proof.ProofType != PROOF_TYPE_JWT {
+		i.restore(token, o)
+		writeError(w, http.StatusBadRequest, "invalid_proof", "jwt proof is required")
+		return
+	}
+
+	if err := verifyProofJWT(request.Proof.JWT, o.owner, i.URL, o.cNonce); err != nil {
+		i.restore(token, o)
+		writeError(w, http.StatusBadRequest, "invalid_proof", err.Error())
+		return
+	}
+
+	credential, err := i.issue(o.request)
+	if errors.Is(err, models.ErrNetworkUnavailable) {
+		i.restore(token, o)
+		writeError(w, http.StatusServiceUnavailable, "server_error", err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid_credential_request", err.Error())
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &CredentialResponse{Credential: credential})
+}
+
+// supportedCredential - Check every credential identifier the wallet sent, at least one is required
+func supportedCredential(request *CredentialRequest) bool {
+	if request.CredentialConfigurationID == "" && request.Format == "" {
+		return false
+	}
+
+	if request.CredentialConfigurationID != "" && request.CredentialConfigurationID != CREDENTIAL_CONFIGURATION_ID {
+		return false
+	}
+
+	return request.Format == "" || request.Format == CREDENTIAL_FORMAT
+}
+
+// restore - Give back a token taken by a request that failed in a way the wallet can retry
+func (i *Issuer) restore(token string, o *offer) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if time.Now().Before(o.expiresAt) {
+		i.tokens[token] = o
+	}
+}
+
+// prune - Drop expired offers and tokens, called with mu held
+func (i *Issuer) prune(now time.Time) {
+	for code, o := range i.offers {
+		if now.After(o.expiresAt) {
+			delete(i.offers, code)
+		}
+	}
+
+	for token, o := range i.tokens {
+		if now.After(o.expiresAt) {
+			delete(i.tokens, token)
+		}
+	}
+}
+
+func (i *Issuer) issue(request *protocol.IssueRequest) (string, error) {
+	if err := validators.ValidateProtocol(i.Protocol); err != nil {
+		return "", err
+	}
+
+	request, err := core.SignIssuing(i.Protocol, i.Issuer, request, i.Protocol.SigningFunc)
+	if err != nil {
+		return "", err
+	}
+
+	if i.Publish {
+		if err := protocol.Issue(i.Protocol, request); err != nil {
+			return "", err
+		}
+	}
+
+	b, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(b), nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code string, description string) {
+	writeJSON(w, status, map[string]string{
+		"error":             code,
+		"error_description": description,
+	})
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
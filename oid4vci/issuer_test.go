@@ -0,0 +1,266 @@
+package oid4vci
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	anima "github.com/anima-protocol/anima-go"
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newKey(t *testing.T) (*ecdsa.PrivateKey, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+// passportRequest - Unsigned passport issue request authorized by ownerKey for issuerAddress
+func passportRequest(t *testing.T, ownerKey *ecdsa.PrivateKey, issuerAddress string) *protocol.IssueRequest {
+	t.Helper()
+	ownerAddress := crypto.PubkeyToAddress(ownerKey.PublicKey).Hex()
+
+	authorization, err := core.CreateIssuingAuthorization(&models.IssuingAuthorization{
+		Specs:       models.DOCUMENT_SPECS_PASSPORT,
+		RequestedAt: uint64(time.Now().Unix()),
+		Fields:      map[string]string{},
+		Attributes: map[string]bool{
+			"firstname": true, "lastname": true, "birth_date": true, "nationality": true,
+			"document_number": true, "expiration_date": true, "issuing_country": true,
+		},
+		Owner:  models.AnimaOwner{ID: "owner", PublicAddress: ownerAddress, Chain: models.CHAIN_ETH},
+		Issuer: models.AnimaIssuer{ID: "issuer", PublicAddress: issuerAddress, Chain: models.CHAIN_ETH},
+	}, evm.PrivateKeySigningFunc(ownerKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	request, err := anima.NewIssuance().
+		Document(models.DOCUMENT_SPECS_PASSPORT).
+		ExpiresAt(time.Now().AddDate(1, 0, 0)).
+		Owner(authorization).
+		Attribute("firstname", "Jane").
+		Attribute("lastname", "Doe").
+		Attribute("document_number", "X1234567").
+		Attribute("birth_date", time.Now().AddDate(-30, 0, 0)).
+		Attribute("expiration_date", time.Now().AddDate(3, 0, 0)).
+		Attribute("nationality", "FR").
+		Attribute("issuing_country", "FR").
+		Proof(models.PROOF_SPECS_MANUAL_REVIEW, map[string]interface{}{"reviewer": "r", "reviewed_at": time.Now().Unix(), "decision": "approved"}).
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return request
+}
+
+// newTestIssuer - Issuer served by an httptest server with one offer for a wallet owned by ownerKey
+func newTestIssuer(t *testing.T, ownerKey *ecdsa.PrivateKey) (*Issuer, *httptest.Server, *CredentialOffer) {
+	t.Helper()
+	issuerKey, issuerAddress := newKey(t)
+	request := passportRequest(t, ownerKey, issuerAddress)
+
+	issuer := NewIssuer(
+		&models.Protocol{Network: models.LOCALNET, Chain: models.CHAIN_ETH, SigningFunc: evm.PrivateKeySigningFunc(issuerKey)},
+		&protocol.AnimaIssuer{Id: "issuer", PublicAddress: issuerAddress, Chain: models.CHAIN_ETH},
+		"",
+	)
+	server := httptest.NewServer(issuer)
+	t.Cleanup(server.Close)
+	issuer.URL = server.URL
+
+	offer, err := issuer.Offer(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return issuer, server, offer
+}
+
+func requestToken(t *testing.T, server *httptest.Server, offer *CredentialOffer) *TokenResponse {
+	t.Helper()
+	res, err := server.Client().PostForm(server.URL+TOKEN_PATH, url.Values{
+		"grant_type":          {GRANT_TYPE_PRE_AUTHORIZED},
+		"pre-authorized_code": {offer.Grants[GRANT_TYPE_PRE_AUTHORIZED].PreAuthorizedCode},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	token := &TokenResponse{}
+	if err := json.NewDecoder(res.Body).Decode(token); err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func requestCredential(t *testing.T, server *httptest.Server, token string, jwt string) int {
+	t.Helper()
+	return postCredential(t, server, token, CredentialRequest{
+		CredentialConfigurationID: CREDENTIAL_CONFIGURATION_ID,
+		Proof:                     &Proof{ProofType: PROOF_TYPE_JWT, JWT: jwt},
+	})
+}
+
+func postCredential(t *testing.T, server *httptest.Server, token string, credentialRequest CredentialRequest) int {
+	t.Helper()
+	body, err := json.Marshal(credentialRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, server.URL+CREDENTIAL_PATH, bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	res, err := server.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	return res.StatusCode
+}
+
+func TestCredentialIssuedOncePerToken(t *testing.T) {
+	ownerKey, _ := newKey(t)
+	_, server, offer := newTestIssuer(t, ownerKey)
+	token := requestToken(t, server, offer)
+
+	jwt, err := NewProofJWT(evm.PrivateKeySigningFunc(ownerKey), server.URL, token.CNonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, 8)
+	for n := 0; n < cap(statuses); n++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses <- requestCredential(t, server, token.AccessToken, jwt)
+		}()
+	}
+	wg.Wait()
+	close(statuses)
+
+	issued := 0
+	for status := range statuses {
+		if status == http.StatusOK {
+			issued++
+		}
+	}
+
+	if issued != 1 {
+		t.Errorf("credential issued %d times, want 1", issued)
+	}
+}
+
+func TestInvalidProofKeepsToken(t *testing.T) {
+	ownerKey, _ := newKey(t)
+	otherKey, _ := newKey(t)
+	_, server, offer := newTestIssuer(t, ownerKey)
+	token := requestToken(t, server, offer)
+
+	invalid, err := NewProofJWT(evm.PrivateKeySigningFunc(otherKey), server.URL, token.CNonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := requestCredential(t, server, token.AccessToken, invalid); status != http.StatusBadRequest {
+		t.Fatalf("invalid proof status = %d", status)
+	}
+
+	valid, err := NewProofJWT(evm.PrivateKeySigningFunc(ownerKey), server.URL, token.CNonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if status := requestCredential(t, server, token.AccessToken, valid); status != http.StatusOK {
+		t.Errorf("valid proof after invalid one status = %d", status)
+	}
+}
+
+func TestIssuerPrunesExpiredOffersAndTokens(t *testing.T) {
+	ownerKey, _ := newKey(t)
+	issuer, server, credentialOffer := newTestIssuer(t, ownerKey)
+	requestToken(t, server, credentialOffer)
+
+	issuer.mu.Lock()
+	defer issuer.mu.Unlock()
+	issuer.offers["pending"] = &offer{expiresAt: time.Now().Add(DEFAULT_OFFER_TTL)}
+
+	issuer.prune(time.Now())
+	if len(issuer.offers) != 1 || len(issuer.tokens) != 1 {
+		t.Fatalf("offers = %d, tokens = %d before expiry, want 1", len(issuer.offers), len(issuer.tokens))
+	}
+
+	issuer.prune(time.Now().Add(DEFAULT_OFFER_TTL + time.Second))
+	if len(issuer.offers) != 0 || len(issuer.tokens) != 0 {
+		t.Errorf("offers = %d, tokens = %d after expiry, want 0", len(issuer.offers), len(issuer.tokens))
+	}
+}
+
+func TestCredentialRequestIdentifiers(t *testing.T) {
+	for _, test := range []struct {
+		name            string
+		configurationID string
+		format          string
+		status          int
+	}{
+		{"configuration id", CREDENTIAL_CONFIGURATION_ID, "", http.StatusOK},
+		{"format", "", CREDENTIAL_FORMAT, http.StatusOK},
+		{"both", CREDENTIAL_CONFIGURATION_ID, CREDENTIAL_FORMAT, http.StatusOK},
+		{"none", "", "", http.StatusBadRequest},
+		{"unknown configuration id", "other", CREDENTIAL_FORMAT, http.StatusBadRequest},
+		{"unknown format", CREDENTIAL_CONFIGURATION_ID, "other", http.StatusBadRequest},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ownerKey, _ := newKey(t)
+			_, server, offer := newTestIssuer(t, ownerKey)
+			token := requestToken(t, server, offer)
+
+			jwt, err := NewProofJWT(evm.PrivateKeySigningFunc(ownerKey), server.URL, token.CNonce)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			status := postCredential(t, server, token.AccessToken, CredentialRequest{
+				CredentialConfigurationID: test.configurationID,
+				Format:                    test.format,
+				Proof:                     &Proof{ProofType: PROOF_TYPE_JWT, JWT: jwt},
+			})
+			if status != test.status {
+				t.Errorf("status = %d, want %d", status, test.status)
+			}
+		})
+	}
+}
+
+func TestOfferValidatesRequest(t *testing.T) {
+	ownerKey, _ := newKey(t)
+	issuer, _, _ := newTestIssuer(t, ownerKey)
+
+	request := passportRequest(t, ownerKey, issuer.Issuer.PublicAddress)
+	request.Proof = nil
+
+	if _, err := issuer.Offer(request); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Errorf("err = %v, want %v", err, models.ErrInvalidRequest)
+	}
+}
@@ -0,0 +1,113 @@
+package oid4vci
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const PROOF_MAX_AGE = 5 * time.Minute
+
+// NewProofJWT - Create ES256K proof of possession JWT for the credential endpoint
+func NewProofJWT(signingFunc func([]byte) (string, error), audience string, nonce string) (string, error) {
+	header, err := json.Marshal(proofHeader{Alg: PROOF_JWT_ALG, Typ: PROOF_JWT_TYP})
+	if err != nil {
+		return "", err
+	}
+
+	payload, err := json.Marshal(proofPayload{Aud: audience, Iat: time.Now().Unix(), Nonce: nonce})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := signingFunc(digest[:])
+	if err != nil {
+		return "", err
+	}
+
+	sig, err := hex.DecodeString(strings.TrimPrefix(signature, "0x"))
+	if err != nil {
+		return "", err
+	}
+
+	if len(sig) != 65 {
+		return "", fmt.Errorf("invalid signature length: %d", len(sig))
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sig[:64]), nil
+}
+
+// verifyProofJWT - Check proof of possession JWT was signed by publicAddress for audience and nonce
+func verifyProofJWT(jwt string, publicAddress string, audience string, nonce string) error {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("invalid proof jwt")
+	}
+
+	header := proofHeader{}
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return err
+	}
+
+	if header.Typ != PROOF_JWT_TYP || header.Alg != PROOF_JWT_ALG {
+		return fmt.Errorf("unsupported proof jwt header: %s/%s", header.Typ, header.Alg)
+	}
+
+	payload := proofPayload{}
+	if err := decodeSegment(parts[1], &payload); err != nil {
+		return err
+	}
+
+	if payload.Aud != audience {
+		return fmt.Errorf("invalid proof audience: %s", payload.Aud)
+	}
+
+	if payload.Nonce != nonce {
+		return fmt.Errorf("invalid proof nonce")
+	}
+
+	issuedAt := time.Unix(payload.Iat, 0)
+	if time.Since(issuedAt) > PROOF_MAX_AGE || time.Until(issuedAt) > PROOF_MAX_AGE {
+		return fmt.Errorf("proof jwt is not fresh")
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+
+	if len(signature) != 64 {
+		return fmt.Errorf("invalid signature length: %d", len(signature))
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	for v := byte(0); v < 2; v++ {
+		pubKey, err := crypto.SigToPub(digest[:], append(append([]byte{}, signature...), v))
+		if err != nil {
+			continue
+		}
+
+		if strings.EqualFold(crypto.PubkeyToAddress(*pubKey).String(), publicAddress) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("public address and signer address does not match")
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, v)
+}
@@ -0,0 +1,83 @@
+package oid4vci
+
+const (
+	/* ENDPOINTS */
+	METADATA_PATH   = "/.well-known/openid-credential-issuer"
+	TOKEN_PATH      = "/token"
+	CREDENTIAL_PATH = "/credential"
+
+	/* OID4VCI */
+	GRANT_TYPE_PRE_AUTHORIZED = "urn:ietf:params:oauth:grant-type:pre-authorized_code"
+	OFFER_URI_SCHEME          = "openid-credential-offer"
+	PROOF_TYPE_JWT            = "jwt"
+	PROOF_JWT_TYP             = "openid4vci-proof+jwt"
+	PROOF_JWT_ALG             = "ES256K"
+
+	/* ANIMA */
+	CREDENTIAL_FORMAT           = "anima_issue_request"
+	CREDENTIAL_CONFIGURATION_ID = "AnimaCredential"
+)
+
+type CredentialIssuerMetadata struct {
+	CredentialIssuer                  string                             `json:"credential_issuer"`
+	TokenEndpoint                     string                             `json:"token_endpoint"`
+	CredentialEndpoint                string                             `json:"credential_endpoint"`
+	CredentialConfigurationsSupported map[string]CredentialConfiguration `json:"credential_configurations_supported"`
+}
+
+type CredentialConfiguration struct {
+	Format              string                        `json:"format"`
+	ProofTypesSupported map[string]ProofTypeSupported `json:"proof_types_supported"`
+}
+
+type ProofTypeSupported struct {
+	ProofSigningAlgValuesSupported []string `json:"proof_signing_alg_values_supported"`
+}
+
+type CredentialOffer struct {
+	CredentialIssuer           string                 `json:"credential_issuer"`
+	CredentialConfigurationIDs []string               `json:"credential_configuration_ids"`
+	Grants                     map[string]OfferGrants `json:"grants"`
+}
+
+type OfferGrants struct {
+	PreAuthorizedCode string `json:"pre-authorized_code"`
+}
+
+type TokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int64  `json:"expires_in"`
+	CNonce          string `json:"c_nonce"`
+	CNonceExpiresIn int64  `json:"c_nonce_expires_in"`
+}
+
+type CredentialRequest struct {
+	Format                    string `json:"format,omitempty"`
+	CredentialConfigurationID string `json:"credential_configuration_id,omitempty"`
+	Proof                     *Proof `json:"proof"`
+}
+
+type Proof struct {
+	ProofType string `json:"proof_type"`
+	JWT       string `json:"jwt"`
+}
+
+type CredentialResponse struct {
+	Credential      string `json:"credential"`
+	CNonce          string `json:"c_nonce,omitempty"`
+	CNonceExpiresIn int64  `json:"c_nonce_expires_in,omitempty"`
+}
+
+type proofHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ"`
+	Kid string `json:"kid,omitempty"`
+}
+
+type proofPayload struct {
+	Iss   string `json:"iss,omitempty"`
+	Aud   string `json:"aud"`
+	Iat   int64  `json:"iat"`
+	Nonce string `json:"nonce"`
+}
@@ -0,0 +1,76 @@
+package oid4vp
+
+import (
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+const (
+	/* OID4VP */
+	RESPONSE_TYPE_VP_TOKEN   = "vp_token"
+	RESPONSE_MODE_DIRECT     = "direct_post"
+	CLIENT_ID_SCHEME_URI     = "redirect_uri"
+	AUTHORIZATION_URI_SCHEME = "openid4vp"
+
+	/* ANIMA */
+	PRESENTATION_SPECS     = "anima:specs:presentation@1.0.0"
+	PRESENTATION_SPECS_JCS = "anima:specs:presentation@1.1.0"
+	PRESENTATION_FORMAT    = "anima_sharing"
+	DESCRIPTOR_ID          = "anima_credentials"
+)
+
+var AVAILABLE_PRESENTATION_SPECS = []string{PRESENTATION_SPECS, PRESENTATION_SPECS_JCS}
+
+type AuthorizationRequest struct {
+	ClientID               string                 `json:"client_id"`
+	ClientIDScheme         string                 `json:"client_id_scheme"`
+	ResponseType           string                 `json:"response_type"`
+	ResponseMode           string                 `json:"response_mode"`
+	ResponseURI            string                 `json:"response_uri"`
+	Nonce                  string                 `json:"nonce"`
+	State                  string                 `json:"state"`
+	PresentationDefinition PresentationDefinition `json:"presentation_definition"`
+}
+
+type PresentationDefinition struct {
+	ID               string            `json:"id"`
+	InputDescriptors []InputDescriptor `json:"input_descriptors"`
+}
+
+type InputDescriptor struct {
+	ID          string                 `json:"id"`
+	Format      map[string]interface{} `json:"format"`
+	Constraints Constraints            `json:"constraints"`
+}
+
+type Constraints struct {
+	Fields []Field `json:"fields"`
+}
+
+type Field struct {
+	Path []string `json:"path"`
+}
+
+type PresentationSubmission struct {
+	ID            string          `json:"id"`
+	DefinitionID  string          `json:"definition_id"`
+	DescriptorMap []DescriptorMap `json:"descriptor_map"`
+}
+
+type DescriptorMap struct {
+	ID     string `json:"id"`
+	Format string `json:"format"`
+	Path   string `json:"path"`
+}
+
+// Presentation - Sharing authorization bound to an authorization request by the owner
+type Presentation struct {
+	Content   PresentationContent `json:"content"`
+	Signature string              `json:"signature"`
+}
+
+type PresentationContent struct {
+	Specs         string                         `json:"specs"`
+	Audience      string                         `json:"aud"`
+	Nonce         string                         `json:"nonce"`
+	Authorization *protocol.SharingAuthorization `json:"authorization"`
+}
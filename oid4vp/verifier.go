@@ -0,0 +1,329 @@
+package oid4vp
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	anima "github.com/anima-protocol/anima-go"
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/utils"
+)
+
+const DEFAULT_SESSION_TTL = 5 * time.Minute
+
+type session struct {
+	request    *AuthorizationRequest
+	attributes []string
+	expiresAt  time.Time
+	done       bool
+	result     *protocol.VerifyResponse
+	err        error
+}
+
+type Verifier struct {
+	ClientID    string
+	ResponseURI string
+	SessionTTL  time.Duration
+	VerifyFunc  func(*protocol.VerifyRequest) (*protocol.VerifyResponse, error)
+
+	mu       sync.Mutex
+	sessions map[string]*session
+}
+
+// NewVerifier - Create OID4VP verifier validating presentations through Anima Protocol
+func NewVerifier(protocolConfig *models.Protocol, clientID string, responseURI string) *Verifier {
+	return &Verifier{
+		ClientID:    clientID,
+		ResponseURI: responseURI,
+		SessionTTL:  DEFAULT_SESSION_TTL,
+		VerifyFunc: func(request *protocol.VerifyRequest) (*protocol.VerifyResponse, error) {
+			return anima.Verify(protocolConfig, request)
+		},
+		sessions: make(map[string]*session),
+	}
+}
+
+// CreateAuthorizationRequest - Create authorization request asking for the given attributes
+func (v *Verifier) CreateAuthorizationRequest(attributes []string) (*AuthorizationRequest, error) {
+	if len(attributes) == 0 {
+		return nil, fmt.Errorf("at least one attribute must be requested")
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []Field{}
+	for _, attribute := range attributes {
+		fields = append(fields, Field{Path: []string{fmt.Sprintf("$.credentials.%s", attribute)}})
+	}
+
+	request := &AuthorizationRequest{
+		ClientID:       v.ClientID,
+		ClientIDScheme: CLIENT_ID_SCHEME_URI,
+		ResponseType:   RESPONSE_TYPE_VP_TOKEN,
+		ResponseMode:   RESPONSE_MODE_DIRECT,
+		ResponseURI:    v.ResponseURI,
+		Nonce:          nonce,
+		State:          state,
+		PresentationDefinition: PresentationDefinition{
+			ID: state,
+			InputDescriptors: []InputDescriptor{
+				{
+					ID:          DESCRIPTOR_ID,
+					Format:      map[string]interface{}{PRESENTATION_FORMAT: map[string]interface{}{}},
+					Constraints: Constraints{Fields: fields},
+				},
+			},
+		},
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.prune(time.Now())
+	v.sessions[state] = &session{
+		request:    request,
+		attributes: attributes,
+		expiresAt:  time.Now().Add(v.SessionTTL),
+	}
+
+	return request, nil
+}
+
+// URI - Encode authorization request as openid4vp:// URI
+func (r *AuthorizationRequest) URI() (string, error) {
+	definition, err := json.Marshal(r.PresentationDefinition)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{}
+	query.Set("client_id", r.ClientID)
+	query.Set("client_id_scheme", r.ClientIDScheme)
+	query.Set("response_type", r.ResponseType)
+	query.Set("response_mode", r.ResponseMode)
+	query.Set("response_uri", r.ResponseURI)
+	query.Set("nonce", r.Nonce)
+	query.Set("state", r.State)
+	query.Set("presentation_definition", string(definition))
+
+	return fmt.Sprintf("%s://?%s", AUTHORIZATION_URI_SCHEME, query.Encode()), nil
+}
+
+// ParseAuthorizationRequest - Decode openid4vp:// URI into authorization request
+func ParseAuthorizationRequest(uri string) (*AuthorizationRequest, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != AUTHORIZATION_URI_SCHEME {
+		return nil, fmt.Errorf("invalid authorization request scheme: %s", u.Scheme)
+	}
+
+	query := u.Query()
+	request := &AuthorizationRequest{
+		ClientID:       query.Get("client_id"),
+		ClientIDScheme: query.Get("client_id_scheme"),
+		ResponseType:   query.Get("response_type"),
+		ResponseMode:   query.Get("response_mode"),
+		ResponseURI:    query.Get("response_uri"),
+		Nonce:          query.Get("nonce"),
+		State:          query.Get("state"),
+	}
+
+	if err := json.Unmarshal([]byte(query.Get("presentation_definition")), &request.PresentationDefinition); err != nil {
+		return nil, err
+	}
+
+	if request.ResponseType != RESPONSE_TYPE_VP_TOKEN || request.ResponseMode != RESPONSE_MODE_DIRECT {
+		return nil, fmt.Errorf("unsupported response type or mode")
+	}
+
+	return request, nil
+}
+
+// ServeHTTP - Handle direct_post authorization response
+func (v *Verifier) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		writeError(w, "invalid_request", err)
+		return
+	}
+
+	if _, err := v.ProcessResponse(r.PostForm); err != nil {
+		writeError(w, "invalid_request", err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte("{}"))
+}
+
+// ProcessResponse - Validate direct_post authorization response and store verification result
+func (v *Verifier) ProcessResponse(form url.Values) (*protocol.VerifyResponse, error) {
+	state := form.Get("state")
+
+	v.mu.Lock()
+	v.prune(time.Now())
+	s, ok := v.sessions[state]
+	if !ok || s.done || time.Now().After(s.expiresAt) {
+		v.mu.Unlock()
+		return nil, fmt.Errorf("unknown or expired state")
+	}
+	s.done = true
+	v.mu.Unlock()
+
+	result, err := v.validate(s, form)
+
+	v.mu.Lock()
+	s.result = result
+	s.err = err
+	v.mu.Unlock()
+
+	return result, err
+}
+
+// Result - Get verification result of an authorization request
+func (v *Verifier) Result(state string) (*protocol.VerifyResponse, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.prune(time.Now())
+
+	s, ok := v.sessions[state]
+	if !ok {
+		return nil, fmt.Errorf("unknown state")
+	}
+
+	if !s.done {
+		return nil, fmt.Errorf("presentation pending")
+	}
+
+	delete(v.sessions, state)
+	return s.result, s.err
+}
+
+// prune - Drop expired pending sessions, and completed sessions whose result was not collected within another SessionTTL
+func (v *Verifier) prune(now time.Time) {
+	for state, s := range v.sessions {
+		expiresAt := s.expiresAt
+		if s.done {
+			expiresAt = expiresAt.Add(v.SessionTTL)
+		}
+
+		if now.After(expiresAt) {
+			delete(v.sessions, state)
+		}
+	}
+}
+
+func (v *Verifier) validate(s *session, form url.Values) (*protocol.VerifyResponse, error) {
+	submission := PresentationSubmission{}
+	if err := json.Unmarshal([]byte(form.Get("presentation_submission")), &submission); err != nil {
+		return nil, fmt.Errorf("invalid presentation_submission: %v", err)
+	}
+
+	if submission.DefinitionID != s.request.PresentationDefinition.ID {
+		return nil, fmt.Errorf("presentation_submission does not match presentation definition")
+	}
+
+	if len(submission.DescriptorMap) != 1 || submission.DescriptorMap[0].ID != DESCRIPTOR_ID || submission.DescriptorMap[0].Format != PRESENTATION_FORMAT {
+		return nil, fmt.Errorf("unsupported presentation_submission descriptor")
+	}
+
+	vpToken, err := base64.RawURLEncoding.DecodeString(form.Get("vp_token"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid vp_token: %v", err)
+	}
+
+	presentation := Presentation{}
+	if err := json.Unmarshal(vpToken, &presentation); err != nil {
+		return nil, fmt.Errorf("invalid vp_token: %v", err)
+	}
+
+	content := presentation.Content
+	if !utils.InArray(content.Specs, AVAILABLE_PRESENTATION_SPECS) {
+		return nil, fmt.Errorf("unsupported presentation specs: %s", content.Specs)
+	}
+
+	if content.Nonce != s.request.Nonce || content.Audience != v.ClientID {
+		return nil, fmt.Errorf("presentation is not bound to this request")
+	}
+
+	if content.Authorization == nil {
+		return nil, fmt.Errorf("presentation has no sharing authorization")
+	}
+
+	res, err := v.VerifyFunc(&protocol.VerifyRequest{Authorization: content.Authorization})
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Content == nil || res.Content.Owner == nil {
+		return nil, fmt.Errorf("verification has no owner")
+	}
+
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	switch res.Content.Owner.Chain {
+	case models.CHAIN_ETH:
+		valid, err := evm.VerifyPresentation(res.Content.Owner.PublicAddress, crypto.SpecsVersion(content.Specs), contentBytes, presentation.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		if !valid {
+			return nil, models.NewError(models.ErrBadSignature, "invalid presentation signature")
+		}
+	default:
+		return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+	}
+
+	for _, attribute := range s.attributes {
+		if _, ok := res.Content.Credentials[attribute]; !ok {
+			return nil, fmt.Errorf("missing requested attribute: %s", attribute)
+		}
+	}
+
+	return res, nil
+}
+
+func writeError(w http.ResponseWriter, code string, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string]string{
+		"error":             code,
+		"error_description": err.Error(),
+	})
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,164 @@
+package oid4vp
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/core"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newTestVerifier(t *testing.T, ownerAddress string) *Verifier {
+	t.Helper()
+	verifier := NewVerifier(nil, "https://verifier.example", "")
+
+	// Stands in for the Anima Protocol: grants every attribute of a valid sharing authorization
+	verifier.VerifyFunc = func(request *protocol.VerifyRequest) (*protocol.VerifyResponse, error) {
+		authorization, err := core.GetSharingAuthorization(request.Authorization)
+		if err != nil {
+			return nil, err
+		}
+
+		credentials := make(map[string]*protocol.AnimaCredentialAttribute)
+		for _, attribute := range authorization.Attributes {
+			credentials[attribute] = &protocol.AnimaCredentialAttribute{}
+		}
+
+		return &protocol.VerifyResponse{Content: &protocol.VerificationContent{
+			Owner:       &protocol.AnimaOwner{PublicAddress: ownerAddress, Chain: models.CHAIN_ETH},
+			Credentials: credentials,
+		}}, nil
+	}
+	return verifier
+}
+
+func newTestWallet(t *testing.T, specsVersion string) (*Wallet, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	signingFunc := evm.PrivateKeySigningFunc(key)
+
+	wallet := &Wallet{
+		Protocol: &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: specsVersion, SigningFunc: signingFunc},
+		Authorize: func(request *AuthorizationRequest) (*protocol.SharingAuthorization, error) {
+			attributes := []string{}
+			for _, field := range request.PresentationDefinition.InputDescriptors[0].Constraints.Fields {
+				attributes = append(attributes, field.Path[0][len("$.credentials."):])
+			}
+
+			return core.CreateSharingAuthorization(&models.SharingAuthorization{
+				RequestedAt: uint64(time.Now().Unix()),
+				Attributes:  attributes,
+				Owner:       models.AnimaOwner{ID: "owner", PublicAddress: address, Chain: models.CHAIN_ETH},
+				Verifier:    models.AnimaVerifier{ID: "verifier", PublicAddress: address, Chain: models.CHAIN_ETH},
+			}, signingFunc)
+		},
+	}
+	return wallet, address
+}
+
+func TestWalletDirectPost(t *testing.T) {
+	for _, specsVersion := range []string{models.SPECS_VERSION_LEGACY, models.SPECS_VERSION_JCS} {
+		t.Run(specsVersion, func(t *testing.T) {
+			wallet, address := newTestWallet(t, specsVersion)
+			verifier := newTestVerifier(t, address)
+
+			server := httptest.NewServer(verifier)
+			defer server.Close()
+			verifier.ResponseURI = server.URL
+			wallet.HTTPClient = server.Client()
+
+			request, err := verifier.CreateAuthorizationRequest([]string{"firstname", "nationality"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			uri, err := request.URI()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := wallet.Present(uri); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := verifier.Result(request.State)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(result.Content.Credentials) != 2 {
+				t.Errorf("credentials = %d, want 2", len(result.Content.Credentials))
+			}
+
+			if err := wallet.Present(uri); err == nil {
+				t.Error("presentation replayed")
+			}
+		})
+	}
+}
+
+func TestWalletPresentationFromOtherOwner(t *testing.T) {
+	wallet, _ := newTestWallet(t, models.SPECS_VERSION_LEGACY)
+	_, otherAddress := newTestWallet(t, models.SPECS_VERSION_LEGACY)
+	verifier := newTestVerifier(t, otherAddress)
+
+	request, err := verifier.CreateAuthorizationRequest([]string{"firstname"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	form, err := wallet.Respond(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := verifier.ProcessResponse(form); err == nil {
+		t.Error("accepted a presentation not signed by the owner")
+	}
+}
+
+func TestVerifierPrunesExpiredSessions(t *testing.T) {
+	verifier := NewVerifier(nil, "https://verifier.example", "https://verifier.example/response")
+
+	expired, err := verifier.CreateAuthorizationRequest([]string{"firstname"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.sessions[expired.State].expiresAt = time.Now().Add(-time.Second)
+
+	completed, err := verifier.CreateAuthorizationRequest([]string{"firstname"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier.sessions[completed.State].done = true
+	verifier.sessions[completed.State].expiresAt = time.Now().Add(-time.Second)
+
+	pending, err := verifier.CreateAuthorizationRequest([]string{"firstname"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier.prune(time.Now())
+	if _, ok := verifier.sessions[expired.State]; ok {
+		t.Error("expired pending session kept")
+	}
+	if _, ok := verifier.sessions[completed.State]; !ok {
+		t.Error("completed session dropped before its result could be collected")
+	}
+	if _, ok := verifier.sessions[pending.State]; !ok {
+		t.Error("pending session dropped")
+	}
+
+	verifier.prune(time.Now().Add(2 * DEFAULT_SESSION_TTL))
+	if len(verifier.sessions) != 0 {
+		t.Errorf("sessions = %d after both TTLs, want 0", len(verifier.sessions))
+	}
+}
@@ -0,0 +1,111 @@
+package oid4vp
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+// Wallet - In-process wallet simulator answering authorization requests
+type Wallet struct {
+	Protocol   *models.Protocol
+	Authorize  func(request *AuthorizationRequest) (*protocol.SharingAuthorization, error)
+	HTTPClient *http.Client
+}
+
+// Respond - Build direct_post form answering an authorization request
+func (w *Wallet) Respond(request *AuthorizationRequest) (url.Values, error) {
+	authorization, err := w.Authorize(request)
+	if err != nil {
+		return nil, err
+	}
+
+	specs := PRESENTATION_SPECS
+	if w.Protocol.GetSpecsVersion() == models.SPECS_VERSION_JCS {
+		specs = PRESENTATION_SPECS_JCS
+	}
+
+	content := PresentationContent{
+		Specs:         specs,
+		Audience:      request.ClientID,
+		Nonce:         request.Nonce,
+		Authorization: authorization,
+	}
+
+	contentBytes, err := json.Marshal(content)
+	if err != nil {
+		return nil, err
+	}
+
+	presentation := Presentation{Content: content}
+	switch w.Protocol.Chain {
+	case models.CHAIN_ETH:
+		signature, err := evm.SignPresentation(w.Protocol, contentBytes, w.Protocol.SigningFunc)
+		if err != nil {
+			return nil, err
+		}
+
+		presentation.Signature = "0x" + strings.TrimPrefix(signature, "0x")
+	default:
+		return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+	}
+
+	vpToken, err := json.Marshal(presentation)
+	if err != nil {
+		return nil, err
+	}
+
+	submission, err := json.Marshal(PresentationSubmission{
+		ID:           request.State,
+		DefinitionID: request.PresentationDefinition.ID,
+		DescriptorMap: []DescriptorMap{
+			{ID: DESCRIPTOR_ID, Format: PRESENTATION_FORMAT, Path: "$"},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("vp_token", base64.RawURLEncoding.EncodeToString(vpToken))
+	form.Set("presentation_submission", string(submission))
+	form.Set("state", request.State)
+	return form, nil
+}
+
+// Present - Parse openid4vp:// URI and post the response to the verifier response_uri
+func (w *Wallet) Present(uri string) error {
+	request, err := ParseAuthorizationRequest(uri)
+	if err != nil {
+		return err
+	}
+
+	form, err := w.Respond(request)
+	if err != nil {
+		return err
+	}
+
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	res, err := client.PostForm(request.ResponseURI, form)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("verifier rejected presentation: %s", res.Status)
+	}
+
+	return nil
+}
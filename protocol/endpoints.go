@@ -2,89 +2,122 @@ package protocol
 
 import (
 	context "context"
-	"errors"
 
-	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/logger"
 	"github.com/anima-protocol/anima-go/models"
 	"github.com/anima-protocol/anima-go/utils"
-	"google.golang.org/grpc/metadata"
 )
 
 func Issue(anima *models.Protocol, req *IssueRequest) error {
-	config := &Config{Secure: anima.Secure}
+	return IssueContext(context.Background(), anima, req)
+}
+
+// IssueContext - Issue with a caller context for cancellation and deadlines
+func IssueContext(ctx context.Context, anima *models.Protocol, req *IssueRequest) error {
+	config := &Config{Secure: anima.Secure, TLS: anima.TLS}
 	err := Init(config, anima)
 	if err != nil {
 		return err
 	}
 
 	if !utils.InArray(anima.Chain, []string{"ETH"}) {
-		return errors.New("unsupported chain")
+		return models.NewError(models.ErrUnsupportedChain, "unsupported chain: %s", anima.Chain)
 	}
 
-	signature, err := evm.SignProtocolRequest(anima, req, anima.SigningFunc)
-	if err != nil {
-		return err
+	key := IdempotencyKey(req)
+	if key == "" {
+		key = MessageIdempotencyKey(req)
 	}
 
-	header := metadata.New(map[string]string{"signature": signature, "chain": anima.Chain})
-	ctx := metadata.NewOutgoingContext(context.Background(), header)
+	logger.Debug("anima protocol request", requestAttrs("issue", anima, logger.String("document_specs", req.GetDocument().GetSpecs()), logger.String("proof_specs", req.GetProof().GetSpecs()))...)
+	err = withRetry(ctx, anima, "issue", key, func(ctx context.Context) error {
+		ctx, err := signedContext(ctx, anima, METHOD_ISSUE, req)
+		if err != nil {
+			return err
+		}
 
-	if _, err = client.Issue(ctx, req); err != nil {
+		_, err = client.Issue(ctx, req)
 		return err
+	})
+	if err != nil {
+		logger.Warn("anima protocol request failed", requestAttrs("issue", anima, logger.Err(err))...)
+		return statusError(err)
 	}
 	return nil
 }
 
 func Verify(anima *models.Protocol, req *VerifyRequest) (*VerifyResponse, error) {
-	config := &Config{Secure: anima.Secure}
+	config := &Config{Secure: anima.Secure, TLS: anima.TLS}
 	err := Init(config, anima)
 	if err != nil {
 		return &VerifyResponse{}, err
 	}
 
 	if !utils.InArray(anima.Chain, []string{"ETH"}) {
-		return &VerifyResponse{}, errors.New("unsupported chain")
+		return &VerifyResponse{}, models.NewError(models.ErrUnsupportedChain, "unsupported chain: %s", anima.Chain)
 	}
 
-	signature, err := evm.SignProtocolRequest(anima, req, anima.SigningFunc)
-	if err != nil {
-		return &VerifyResponse{}, err
-	}
+	logger.Debug("anima protocol request", requestAttrs("verify", anima, logger.String("authorization_specs", req.GetAuthorization().GetSpecs()))...)
+	var res *VerifyResponse
+	err = withRetry(context.Background(), anima, "verify", MessageIdempotencyKey(req), func(ctx context.Context) error {
+		ctx, err := signedContext(ctx, anima, METHOD_VERIFY, req)
+		if err != nil {
+			return err
+		}
 
-	header := metadata.New(map[string]string{"signature": signature, "chain": anima.Chain})
-	ctx := metadata.NewOutgoingContext(context.Background(), header)
-
-	res, err := client.Verify(ctx, req)
+		res, err = client.Verify(ctx, req)
+		return err
+	})
 	if err != nil {
-		return &VerifyResponse{}, err
+		logger.Warn("anima protocol request failed", requestAttrs("verify", anima, logger.Err(err))...)
+		return &VerifyResponse{}, statusError(err)
 	}
 
 	return res, nil
 }
 
 func RegisterVerifier(anima *models.Protocol, req *RegisterVerifierRequest) (*RegisterVerifierResponse, error) {
-	config := &Config{Secure: anima.Secure}
+	config := &Config{Secure: anima.Secure, TLS: anima.TLS}
 	err := Init(config, anima)
 	if err != nil {
 		return &RegisterVerifierResponse{}, err
 	}
 
 	if !utils.InArray(anima.Chain, []string{"ETH"}) {
-		return &RegisterVerifierResponse{}, errors.New("unsupported chain")
+		return &RegisterVerifierResponse{}, models.NewError(models.ErrUnsupportedChain, "unsupported chain: %s", anima.Chain)
 	}
 
-	signature, err := evm.SignProtocolRequest(anima, req, anima.SigningFunc)
+	logger.Debug("anima protocol request", requestAttrs("register_verifier", anima)...)
+	var res *RegisterVerifierResponse
+	err = withRetry(context.Background(), anima, "register_verifier", MessageIdempotencyKey(req), func(ctx context.Context) error {
+		ctx, err := signedContext(ctx, anima, METHOD_REGISTER_VERIFIER, req)
+		if err != nil {
+			return err
+		}
+
+		res, err = client.RegisterVerifier(ctx, req)
+		return err
+	})
 	if err != nil {
-		return &RegisterVerifierResponse{}, err
+		logger.Warn("anima protocol request failed", requestAttrs("register_verifier", anima, logger.Err(err))...)
+		return &RegisterVerifierResponse{}, statusError(err)
 	}
 
-	header := metadata.New(map[string]string{"signature": signature, "chain": anima.Chain})
-	ctx := metadata.NewOutgoingContext(context.Background(), header)
+	return res, nil
+}
 
-	res, err := client.RegisterVerifier(ctx, req)
-	if err != nil {
-		return &RegisterVerifierResponse{}, err
+// withTimeout - Bound a call attempt with the protocol timeout when set
+func withTimeout(ctx context.Context, anima *models.Protocol) (context.Context, context.CancelFunc) {
+	if anima.Timeout <= 0 {
+		return context.WithCancel(ctx)
 	}
+	return context.WithTimeout(ctx, anima.Timeout)
+}
 
-	return res, nil
+func requestAttrs(kind string, anima *models.Protocol, attrs ...logger.Attr) []logger.Attr {
+	return append([]logger.Attr{
+		logger.String("request", kind),
+		logger.String("chain", anima.Chain),
+		logger.String("specs_version", anima.GetSpecsVersion()),
+	}, attrs...)
 }
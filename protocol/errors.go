@@ -0,0 +1,22 @@
+package protocol
+
+import (
+	"github.com/anima-protocol/anima-go/models"
+	"google.golang.org/grpc/status"
+)
+
+// statusError - Translate an Anima service error into a ProtocolError
+func statusError(err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		// Not from the service, e.g. request signing failed
+		return err
+	}
+
+	return &models.ProtocolError{
+		Code:    st.Code(),
+		Message: st.Message(),
+		Details: st.Details(),
+		Cause:   err,
+	}
+}
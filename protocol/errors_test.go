@@ -0,0 +1,41 @@
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestStatusErrorTranslatesServiceStatus(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "document specs unknown").WithDetails(durationpb.New(time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	translated := statusError(st.Err())
+
+	var protocolErr *models.ProtocolError
+	if !errors.As(translated, &protocolErr) {
+		t.Fatalf("err = %T, want *models.ProtocolError", translated)
+	}
+
+	if protocolErr.Code != codes.InvalidArgument || protocolErr.Message != "document specs unknown" || len(protocolErr.Details) != 1 {
+		t.Errorf("protocol error = %+v", protocolErr)
+	}
+
+	if !errors.Is(translated, models.ErrInvalidRequest) {
+		t.Errorf("%v is not an invalid request", translated)
+	}
+}
+
+func TestStatusErrorKeepsLocalErrors(t *testing.T) {
+	local := models.NewError(models.ErrBadSignature, "could not sign request")
+	if translated := statusError(local); translated != error(local) {
+		t.Errorf("err = %v, want it unchanged", translated)
+	}
+}
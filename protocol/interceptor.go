@@ -0,0 +1,106 @@
+package protocol
+
+import (
+	"context"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/logger"
+	"github.com/anima-protocol/anima-go/models"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Caller - Authenticated signer of a protocol request
+type Caller struct {
+	PublicAddress string
+	Chain         string
+	SpecsVersion  string
+	// Claims - Replay protection claims, nil for legacy requests accepted with ReplayGuard.AllowLegacy
+	Claims *models.ProtocolRequestClaims
+}
+
+type callerKey struct{}
+
+func ContextWithCaller(ctx context.Context, caller *Caller) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext - Caller injected by UnaryServerInterceptor
+func CallerFromContext(ctx context.Context) (*Caller, bool) {
+	caller, ok := ctx.Value(callerKey{}).(*Caller)
+	return caller, ok
+}
+
+// UnaryServerInterceptor - Authenticate Anima signed requests and inject their Caller
+//
+// Requests must carry fresh replay protection claims, unless guard.AllowLegacy
+// accepts requests signed over their body alone. Without guard every request is rejected.
+func UnaryServerInterceptor(guard *ReplayGuard) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if guard == nil {
+			logger.Error("anima request rejected, no replay guard configured", logger.String("method", info.FullMethod))
+			return nil, status.Error(codes.Internal, "request authentication is not configured")
+		}
+
+		caller, err := authenticate(ctx, req, info.FullMethod, guard)
+		if err != nil {
+			logger.Debug("anima request rejected", logger.String("method", info.FullMethod), logger.Err(err))
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		return handler(ContextWithCaller(ctx, caller), req)
+	}
+}
+
+func authenticate(ctx context.Context, req interface{}, method string, guard *ReplayGuard) (*Caller, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, models.NewError(models.ErrBadSignature, "missing request metadata")
+	}
+
+	signature := firstMetadata(md, METADATA_SIGNATURE)
+	if signature == "" {
+		return nil, models.NewError(models.ErrBadSignature, "missing %s metadata", METADATA_SIGNATURE)
+	}
+
+	caller := &Caller{
+		Chain:        firstMetadata(md, METADATA_CHAIN),
+		SpecsVersion: firstMetadata(md, METADATA_SPECS_VERSION),
+	}
+	if caller.SpecsVersion == "" {
+		caller.SpecsVersion = models.SPECS_VERSION_LEGACY
+	}
+
+	if caller.Chain != models.CHAIN_ETH {
+		return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain: %s", caller.Chain)
+	}
+
+	var digest []byte
+	var err error
+	if firstMetadata(md, METADATA_NONCE) != "" || !guard.AllowLegacy {
+		if caller.Claims, err = RequestClaimsFromMetadata(md); err != nil {
+			return nil, err
+		}
+		digest, err = evm.ProtocolRequestDigest(caller.SpecsVersion, req, caller.Claims)
+	} else {
+		digest, err = evm.LegacyProtocolRequestDigest(caller.SpecsVersion, req)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if caller.PublicAddress, err = evm.RecoverAddress(digest, signature); err != nil {
+		return nil, err
+	}
+
+	// Nonces are only consumed once the signature proves the claims genuine
+	if caller.Claims != nil {
+		if err := guard.Check(ctx, method, caller.Claims); err != nil {
+			return nil, err
+		}
+	}
+
+	return caller, nil
+}
@@ -0,0 +1,188 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func signer(t *testing.T) (*models.Protocol, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anima := &models.Protocol{Network: models.TESTNET, Chain: models.CHAIN_ETH, SigningFunc: evm.PrivateKeySigningFunc(key)}
+	return anima, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+// incoming - Server side context of req signed by anima for method
+func incoming(t *testing.T, anima *models.Protocol, method string, req interface{}) context.Context {
+	t.Helper()
+	ctx, err := signedContext(context.Background(), anima, method, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	md, _ := metadata.FromOutgoingContext(ctx)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// legacyIncoming - Server side context of req signed over its body alone
+func legacyIncoming(t *testing.T, anima *models.Protocol, req interface{}) context.Context {
+	t.Helper()
+	signature, err := evm.SignProtocolRequest(anima, req, anima.SigningFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs(METADATA_SIGNATURE, signature, METADATA_CHAIN, anima.Chain))
+}
+
+// changeMetadata - Copy of an incoming context with changed metadata
+func changeMetadata(ctx context.Context, change func(md metadata.MD)) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	md = md.Copy()
+	change(md)
+	return metadata.NewIncomingContext(context.Background(), md)
+}
+
+// intercept - Run the interceptor, returning the caller the handler received
+func intercept(ctx context.Context, guard *ReplayGuard, req interface{}) (*Caller, error) {
+	var caller *Caller
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		caller, _ = CallerFromContext(ctx)
+		return req, nil
+	}
+
+	_, err := UnaryServerInterceptor(guard)(ctx, req, &grpc.UnaryServerInfo{FullMethod: METHOD_VERIFY}, handler)
+	return caller, err
+}
+
+func verifyRequest() *VerifyRequest {
+	return &VerifyRequest{Authorization: &SharingAuthorization{Specs: "anima:specs:sharing/authorization@1.0.0", Content: "{}", Signature: "0x01"}}
+}
+
+func TestUnaryServerInterceptorInjectsCaller(t *testing.T) {
+	anima, address := signer(t)
+	req := verifyRequest()
+
+	caller, err := intercept(incoming(t, anima, METHOD_VERIFY, req), NewReplayGuard(models.TESTNET, NewMemoryNonceStore()), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if caller == nil || caller.PublicAddress != address || caller.Chain != models.CHAIN_ETH || caller.Claims == nil || caller.Claims.Method != METHOD_VERIFY {
+		t.Errorf("caller = %+v, want the signer %s", caller, address)
+	}
+}
+
+func TestUnaryServerInterceptorRejects(t *testing.T) {
+	anima, _ := signer(t)
+	req := verifyRequest()
+
+	signed := incoming(t, anima, METHOD_VERIFY, req)
+	for name, ctx := range map[string]context.Context{
+		"missing metadata":  context.Background(),
+		"missing signature": changeMetadata(signed, func(md metadata.MD) { md.Delete(METADATA_SIGNATURE) }),
+		"bad signature":     changeMetadata(signed, func(md metadata.MD) { md.Set(METADATA_SIGNATURE, "0x1234") }),
+		"other chain":       changeMetadata(signed, func(md metadata.MD) { md.Set(METADATA_CHAIN, "SOL") }),
+		"other method":      incoming(t, anima, METHOD_ISSUE, req),
+		"legacy signature":  legacyIncoming(t, anima, req),
+	} {
+		t.Run(name, func(t *testing.T) {
+			caller, err := intercept(ctx, NewReplayGuard(models.TESTNET, NewMemoryNonceStore()), req)
+			if status.Code(err) != codes.Unauthenticated || caller != nil {
+				t.Errorf("caller = %+v, err = %v, want %s", caller, err, codes.Unauthenticated)
+			}
+		})
+	}
+}
+
+func TestUnaryServerInterceptorOtherRequest(t *testing.T) {
+	anima, address := signer(t)
+	req := verifyRequest()
+	other := verifyRequest()
+	other.Authorization.Signature = "0x02"
+
+	// A signature over another body recovers another address, never the signer
+	caller, err := intercept(incoming(t, anima, METHOD_VERIFY, other), NewReplayGuard(models.TESTNET, NewMemoryNonceStore()), req)
+	if err == nil && caller.PublicAddress == address {
+		t.Errorf("request authenticated as %s with the signature of another request", address)
+	}
+}
+
+func TestUnaryServerInterceptorRejectsReplay(t *testing.T) {
+	anima, _ := signer(t)
+	req := verifyRequest()
+	guard := NewReplayGuard(models.TESTNET, NewMemoryNonceStore())
+	ctx := incoming(t, anima, METHOD_VERIFY, req)
+
+	if _, err := intercept(ctx, guard, req); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := intercept(ctx, guard, req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("replayed request err = %v, want %s", err, codes.Unauthenticated)
+	}
+}
+
+func TestUnaryServerInterceptorRequiresGuard(t *testing.T) {
+	anima, _ := signer(t)
+	req := verifyRequest()
+
+	for name, ctx := range map[string]context.Context{
+		"signed":        incoming(t, anima, METHOD_VERIFY, req),
+		"legacy signed": legacyIncoming(t, anima, req),
+	} {
+		if caller, err := intercept(ctx, nil, req); status.Code(err) != codes.Internal || caller != nil {
+			t.Errorf("%s: caller = %+v, err = %v, want %s", name, caller, err, codes.Internal)
+		}
+	}
+}
+
+func TestUnaryServerInterceptorAllowLegacy(t *testing.T) {
+	anima, address := signer(t)
+	req := verifyRequest()
+	guard := NewReplayGuard(models.TESTNET, NewMemoryNonceStore())
+	guard.AllowLegacy = true
+
+	caller, err := intercept(legacyIncoming(t, anima, req), guard, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if caller.PublicAddress != address || caller.Claims != nil || caller.SpecsVersion != models.SPECS_VERSION_LEGACY {
+		t.Errorf("legacy caller = %+v", caller)
+	}
+
+	// Requests carrying claims are still checked for replay
+	ctx := incoming(t, anima, METHOD_VERIFY, req)
+	intercept(ctx, guard, req)
+	if _, err := intercept(ctx, guard, req); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("replayed request err = %v, want %s", err, codes.Unauthenticated)
+	}
+}
+
+func TestAuthenticateErrorKinds(t *testing.T) {
+	anima, _ := signer(t)
+	req := verifyRequest()
+	guard := NewReplayGuard(models.TESTNET, NewMemoryNonceStore())
+
+	if _, err := authenticate(context.Background(), req, METHOD_VERIFY, guard); !errors.Is(err, models.ErrBadSignature) {
+		t.Errorf("missing metadata err = %v, want %v", err, models.ErrBadSignature)
+	}
+
+	if _, err := authenticate(legacyIncoming(t, anima, req), req, METHOD_VERIFY, guard); !errors.Is(err, models.ErrInvalidRequest) {
+		t.Errorf("legacy signature err = %v, want %v", err, models.ErrInvalidRequest)
+	}
+}
@@ -0,0 +1,102 @@
+package protocol
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/models"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	/* METHODS */
+	METHOD_ISSUE             = "/anima.Anima/Issue"
+	METHOD_VERIFY            = "/anima.Anima/Verify"
+	METHOD_REGISTER_VERIFIER = "/anima.Anima/RegisterVerifier"
+
+	/* METADATA */
+	METADATA_SIGNATURE       = "signature"
+	METADATA_CHAIN           = "chain"
+	METADATA_SPECS_VERSION   = "specs_version"
+	METADATA_METHOD          = "method"
+	METADATA_NETWORK         = "network"
+	METADATA_TIMESTAMP       = "timestamp"
+	METADATA_NONCE           = "nonce"
+	METADATA_IDEMPOTENCY_KEY = "idempotency-key"
+)
+
+// NewRequestClaims - Fresh replay protection claims for a call of method on the protocol network
+func NewRequestClaims(anima *models.Protocol, method string, now time.Time) (*models.ProtocolRequestClaims, error) {
+	nonce := make([]byte, models.PROTOCOL_REQUEST_NONCE_SIZE)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	return &models.ProtocolRequestClaims{
+		Method:    method,
+		Network:   anima.Network,
+		Timestamp: now.Unix(),
+		Nonce:     hex.EncodeToString(nonce),
+	}, nil
+}
+
+// signedContext - Outgoing context carrying the request signature and its claims
+func signedContext(ctx context.Context, anima *models.Protocol, method string, req interface{}) (context.Context, error) {
+	claims, err := NewRequestClaims(anima, method, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := evm.SignProtocolRequestClaims(anima, req, claims, anima.SigningFunc)
+	if err != nil {
+		return nil, err
+	}
+
+	header := metadata.New(map[string]string{
+		METADATA_SIGNATURE:     signature,
+		METADATA_CHAIN:         anima.Chain,
+		METADATA_SPECS_VERSION: anima.GetSpecsVersion(),
+		METADATA_METHOD:        claims.Method,
+		METADATA_NETWORK:       claims.Network,
+		METADATA_TIMESTAMP:     strconv.FormatInt(claims.Timestamp, 10),
+		METADATA_NONCE:         claims.Nonce,
+	})
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok {
+		header = metadata.Join(md, header)
+	}
+	return metadata.NewOutgoingContext(ctx, header), nil
+}
+
+// RequestClaimsFromMetadata - Replay protection claims of an incoming request
+func RequestClaimsFromMetadata(md metadata.MD) (*models.ProtocolRequestClaims, error) {
+	claims := &models.ProtocolRequestClaims{
+		Method:  firstMetadata(md, METADATA_METHOD),
+		Network: firstMetadata(md, METADATA_NETWORK),
+		Nonce:   firstMetadata(md, METADATA_NONCE),
+	}
+
+	timestamp := firstMetadata(md, METADATA_TIMESTAMP)
+	if claims.Method == "" || claims.Network == "" || claims.Nonce == "" || timestamp == "" {
+		return nil, models.NewError(models.ErrInvalidRequest, "missing replay protection metadata")
+	}
+
+	var err error
+	if claims.Timestamp, err = strconv.ParseInt(timestamp, 10, 64); err != nil {
+		return nil, models.NewError(models.ErrInvalidRequest, "invalid %s metadata", METADATA_TIMESTAMP)
+	}
+
+	return claims, nil
+}
+
+func firstMetadata(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
@@ -0,0 +1,137 @@
+package protocol
+
+import (
+	"container/heap"
+	"context"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+// NonceStore - Record of request nonces already used, e.g. backed by Redis in a cluster
+type NonceStore interface {
+	// Use - Mark nonce used until expiresAt, false when it was already used
+	Use(ctx context.Context, nonce string, expiresAt time.Time) (bool, error)
+}
+
+// MemoryNonceStore - Process local NonceStore
+type MemoryNonceStore struct {
+	mu     sync.Mutex
+	nonces map[string]time.Time
+	expiry nonceExpiry
+	now    func() time.Time
+}
+
+func NewMemoryNonceStore() *MemoryNonceStore {
+	return &MemoryNonceStore{nonces: make(map[string]time.Time), now: time.Now}
+}
+
+func (s *MemoryNonceStore) Use(_ context.Context, nonce string, expiresAt time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Only expired nonces are visited, soonest first
+	now := s.now()
+	for len(s.expiry) > 0 && !s.expiry[0].expiresAt.After(now) {
+		expired := heap.Pop(&s.expiry).(usedNonce)
+		delete(s.nonces, expired.nonce)
+	}
+
+	if _, ok := s.nonces[nonce]; ok {
+		return false, nil
+	}
+
+	s.nonces[nonce] = expiresAt
+	heap.Push(&s.expiry, usedNonce{nonce: nonce, expiresAt: expiresAt})
+	return true, nil
+}
+
+type usedNonce struct {
+	nonce     string
+	expiresAt time.Time
+}
+
+// nonceExpiry - container/heap of used nonces ordered by expiration
+type nonceExpiry []usedNonce
+
+func (h nonceExpiry) Len() int           { return len(h) }
+func (h nonceExpiry) Less(i, j int) bool { return h[i].expiresAt.Before(h[j].expiresAt) }
+func (h nonceExpiry) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *nonceExpiry) Push(x interface{}) {
+	*h = append(*h, x.(usedNonce))
+}
+
+func (h *nonceExpiry) Pop() interface{} {
+	old := *h
+	n := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return n
+}
+
+// ReplayGuard - Server side freshness and nonce uniqueness checks of signed protocol requests
+type ReplayGuard struct {
+	// Network - Network name clients must have signed for
+	Network string
+	// MaxAge - Accepted request age, PROTOCOL_REQUEST_MAX_AGE when zero
+	MaxAge time.Duration
+	// Store - Used nonces, only freshness is checked when nil
+	Store NonceStore
+	Clock func() time.Time
+	// AllowLegacy - Also accept requests signed with evm.SignProtocolRequest, which carry no replay protection
+	AllowLegacy bool
+}
+
+func NewReplayGuard(network string, store NonceStore) *ReplayGuard {
+	return &ReplayGuard{Network: network, Store: store}
+}
+
+// Check - Validate claims of a request received on method, consuming its nonce
+func (g *ReplayGuard) Check(ctx context.Context, method string, claims *models.ProtocolRequestClaims) error {
+	now := time.Now()
+	if g.Clock != nil {
+		now = g.Clock()
+	}
+
+	maxAge := g.MaxAge
+	if maxAge == 0 {
+		maxAge = models.PROTOCOL_REQUEST_MAX_AGE
+	}
+
+	if claims.Method != method {
+		return models.NewError(models.ErrInvalidRequest, "request signed for method %s", claims.Method)
+	}
+
+	if claims.Network != g.Network {
+		return models.NewError(models.ErrInvalidRequest, "request signed for network %s", claims.Network)
+	}
+
+	if nonce, err := hex.DecodeString(claims.Nonce); err != nil || len(nonce) < models.PROTOCOL_REQUEST_NONCE_SIZE {
+		return models.NewError(models.ErrInvalidRequest, "invalid request nonce")
+	}
+
+	timestamp := time.Unix(claims.Timestamp, 0)
+	if timestamp.After(now.Add(models.AUTHORIZATION_CLOCK_SKEW)) {
+		return models.NewError(models.ErrInvalidRequest, "request timestamp is in the future")
+	}
+	if now.Sub(timestamp) > maxAge {
+		return models.NewError(models.ErrAuthorizationExpired, "request is older than %s", maxAge)
+	}
+
+	if g.Store == nil {
+		return nil
+	}
+
+	// Nonces are kept until the request would be stale anyway
+	fresh, err := g.Store.Use(ctx, claims.Nonce, timestamp.Add(maxAge+models.AUTHORIZATION_CLOCK_SKEW))
+	if err != nil {
+		return err
+	}
+
+	if !fresh {
+		return models.NewError(models.ErrReplayedRequest, "request nonce already used")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package protocol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+var replayTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+const NONCE = "000102030405060708090a0b0c0d0e0f"
+
+func TestMemoryNonceStore(t *testing.T) {
+	now := replayTime
+	store := NewMemoryNonceStore()
+	store.now = func() time.Time { return now }
+
+	use := func(nonce string, expiresAt time.Time) bool {
+		t.Helper()
+		fresh, err := store.Use(context.Background(), nonce, expiresAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fresh
+	}
+
+	if !use("a", now.Add(time.Minute)) || use("a", now.Add(time.Hour)) {
+		t.Fatal("nonce reused before its expiration")
+	}
+
+	for i := 0; i < 100; i++ {
+		use(fmt.Sprintf("later-%d", i), now.Add(time.Hour+time.Duration(i)*time.Second))
+	}
+
+	// Past its expiration a nonce is forgotten and may be used again,
+	// nonces expiring later are kept
+	now = now.Add(time.Minute)
+	if !use("a", now.Add(time.Minute)) || use("later-0", now.Add(time.Hour)) {
+		t.Error("expired nonce kept or later nonce forgotten")
+	}
+
+	now = now.Add(2 * time.Hour)
+	use("b", now.Add(time.Minute))
+	if len(store.nonces) != 1 || len(store.expiry) != 1 {
+		t.Errorf("%d nonces, %d expirations kept, want only the last one", len(store.nonces), len(store.expiry))
+	}
+}
+
+func replayGuard() *ReplayGuard {
+	clock := func() time.Time { return replayTime }
+	store := NewMemoryNonceStore()
+	store.now = clock
+
+	guard := NewReplayGuard(models.TESTNET, store)
+	guard.Clock = clock
+	return guard
+}
+
+func claims(age time.Duration) *models.ProtocolRequestClaims {
+	return &models.ProtocolRequestClaims{Method: METHOD_ISSUE, Network: models.TESTNET, Timestamp: replayTime.Add(-age).Unix(), Nonce: NONCE}
+}
+
+func TestReplayGuardCheck(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		change func(claims *models.ProtocolRequestClaims)
+		kind   error
+	}{
+		{"fresh", func(*models.ProtocolRequestClaims) {}, nil},
+		{"max age", func(c *models.ProtocolRequestClaims) {
+			c.Timestamp -= int64(models.PROTOCOL_REQUEST_MAX_AGE / time.Second)
+		}, nil},
+		{"within clock skew", func(c *models.ProtocolRequestClaims) {
+			c.Timestamp += int64(models.AUTHORIZATION_CLOCK_SKEW / time.Second)
+		}, nil},
+		{"stale", func(c *models.ProtocolRequestClaims) {
+			c.Timestamp -= int64(models.PROTOCOL_REQUEST_MAX_AGE/time.Second) + 1
+		}, models.ErrAuthorizationExpired},
+		{"future", func(c *models.ProtocolRequestClaims) {
+			c.Timestamp += int64(models.AUTHORIZATION_CLOCK_SKEW/time.Second) + 1
+		}, models.ErrInvalidRequest},
+		{"method", func(c *models.ProtocolRequestClaims) { c.Method = METHOD_VERIFY }, models.ErrInvalidRequest},
+		{"network", func(c *models.ProtocolRequestClaims) { c.Network = models.MAINNET }, models.ErrInvalidRequest},
+		{"short nonce", func(c *models.ProtocolRequestClaims) { c.Nonce = NONCE[:30] }, models.ErrInvalidRequest},
+		{"nonce encoding", func(c *models.ProtocolRequestClaims) { c.Nonce = strings.Repeat("z", 32) }, models.ErrInvalidRequest},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := claims(0)
+			test.change(request)
+
+			if err := replayGuard().Check(context.Background(), METHOD_ISSUE, request); !errors.Is(err, test.kind) {
+				t.Errorf("err = %v, want %v", err, test.kind)
+			}
+		})
+	}
+}
+
+func TestReplayGuardRejectsReusedNonce(t *testing.T) {
+	guard := replayGuard()
+	if err := guard.Check(context.Background(), METHOD_ISSUE, claims(0)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Signed again later with the same nonce, the request is still a replay
+	if err := guard.Check(context.Background(), METHOD_ISSUE, claims(-time.Minute)); !errors.Is(err, models.ErrReplayedRequest) {
+		t.Errorf("err = %v, want %v", err, models.ErrReplayedRequest)
+	}
+
+	// A rejected request does not consume its nonce
+	stale := claims(time.Hour)
+	stale.Nonce = strings.Repeat("ab", 16)
+	guard.Check(context.Background(), METHOD_ISSUE, stale)
+	stale.Timestamp = replayTime.Unix()
+	if err := guard.Check(context.Background(), METHOD_ISSUE, stale); err != nil {
+		t.Errorf("nonce of a stale request consumed: %v", err)
+	}
+
+	// Without store only freshness is checked
+	guard.Store = nil
+	for i := 0; i < 2; i++ {
+		if err := guard.Check(context.Background(), METHOD_ISSUE, claims(0)); err != nil {
+			t.Errorf("check %d without store: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package protocol
+
+import (
+	"context"
+	"time"
+
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/logger"
+	"github.com/anima-protocol/anima-go/models"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// IdempotencyKey - Key of a signed IssueRequest, derived from the document id its credentials point to
+//
+// Re-submitting the same document, even signed again, yields the same key.
+func IdempotencyKey(req *IssueRequest) string {
+	for _, attribute := range req.GetAttributes() {
+		documentId := attribute.GetCredential().GetContent().GetDocument().GetId()
+		if documentId != "" {
+			return crypto.HashStr(documentId)
+		}
+	}
+	return ""
+}
+
+// MessageIdempotencyKey - Key of a request without document, derived from its deterministic encoding
+func MessageIdempotencyKey(req proto.Message) string {
+	content, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil || len(content) == 0 {
+		return ""
+	}
+	return crypto.Hash(content)
+}
+
+// withRetry - Run call until it succeeds, fails with a non retryable code or attempts run out
+//
+// Every attempt carries the idempotency key so the service applies the request once.
+// Without a key the call is attempted once, as a retry could apply it twice.
+func withRetry(ctx context.Context, anima *models.Protocol, kind string, key string, call func(ctx context.Context) error) error {
+	policy := anima.GetRetryPolicy()
+	maxAttempts := policy.MaxAttempts
+	if key == "" {
+		maxAttempts = 1
+	} else {
+		ctx = metadata.AppendToOutgoingContext(ctx, METADATA_IDEMPOTENCY_KEY, key)
+	}
+
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := withTimeout(ctx, anima)
+		err := call(attemptCtx)
+		cancel()
+
+		if err == nil {
+			return nil
+		}
+
+		code := status.Code(err)
+		if attempt >= maxAttempts || !policy.Retryable(code) || ctx.Err() != nil {
+			return err
+		}
+
+		backoff := policy.Backoff(attempt)
+		logger.Warn("anima protocol request retry",
+			logger.String("request", kind),
+			logger.Int("attempt", attempt),
+			logger.String("code", code.String()),
+			logger.String("backoff", backoff.String()),
+		)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package protocol
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func retryProtocol() *models.Protocol {
+	return &models.Protocol{
+		Timeout: time.Second,
+		Retry: &models.RetryPolicy{
+			MaxAttempts:    3,
+			InitialBackoff: time.Millisecond,
+			Multiplier:     2,
+			RetryableCodes: []codes.Code{codes.Unavailable},
+		},
+	}
+}
+
+// failing - Call failing with code on every attempt, recording the idempotency keys it was sent
+func failing(code codes.Code, keys *[][]string) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		*keys = append(*keys, md.Get(METADATA_IDEMPOTENCY_KEY))
+
+		if _, ok := ctx.Deadline(); !ok {
+			return status.Error(codes.Internal, "attempt without timeout")
+		}
+		return status.Error(code, "failed")
+	}
+}
+
+func TestWithRetry(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		code     codes.Code
+		key      string
+		attempts int
+	}{
+		{"retryable", codes.Unavailable, "key", 3},
+		{"not retryable", codes.InvalidArgument, "key", 1},
+		{"no idempotency key", codes.Unavailable, "", 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var keys [][]string
+			err := withRetry(context.Background(), retryProtocol(), "issue", test.key, failing(test.code, &keys))
+			if status.Code(err) != test.code {
+				t.Errorf("err = %v, want %s", err, test.code)
+			}
+
+			if len(keys) != test.attempts {
+				t.Errorf("attempts = %d, want %d", len(keys), test.attempts)
+			}
+
+			for _, sent := range keys {
+				if (test.key == "" && len(sent) != 0) || (test.key != "" && (len(sent) != 1 || sent[0] != test.key)) {
+					t.Errorf("idempotency keys = %v, want %q once per attempt", sent, test.key)
+				}
+			}
+		})
+	}
+}
+
+func TestWithRetrySucceeds(t *testing.T) {
+	attempts := 0
+	err := withRetry(context.Background(), retryProtocol(), "verify", "key", func(ctx context.Context) error {
+		attempts++
+		if attempts < 2 {
+			return status.Error(codes.Unavailable, "failed")
+		}
+		return nil
+	})
+	if err != nil || attempts != 2 {
+		t.Errorf("err = %v after %d attempts, want success on the second", err, attempts)
+	}
+}
+
+func TestWithRetryStopsOnCancel(t *testing.T) {
+	anima := retryProtocol()
+	anima.Retry.InitialBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var keys [][]string
+	done := make(chan error)
+	go func() {
+		done <- withRetry(ctx, anima, "issue", "key", failing(codes.Unavailable, &keys))
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-done:
+		if status.Code(err) != codes.Unavailable || len(keys) != 1 {
+			t.Errorf("err = %v after %d attempts, want the last error after 1", err, len(keys))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("backoff not interrupted by cancellation")
+	}
+}
+
+func issueRequest(documentId string, signature string) *IssueRequest {
+	return &IssueRequest{
+		Document: &IssDocument{Specs: models.DOCUMENT_SPECS_PASSPORT},
+		Attributes: map[string]*IssAttribute{
+			"firstname": {Credential: &IssAttributeCredential{
+				Content:   &IssAttributeCredentialContent{Document: &IssAttributeCredentialContentDocument{Id: documentId}},
+				Signature: signature,
+			}},
+		},
+	}
+}
+
+func TestIdempotencyKey(t *testing.T) {
+	key := IdempotencyKey(issueRequest("anima:document:1", "0x01"))
+	if key == "" {
+		t.Fatal("no idempotency key for a signed request")
+	}
+
+	if resigned := IdempotencyKey(issueRequest("anima:document:1", "0x02")); resigned != key {
+		t.Error("signing the same document again changed its idempotency key")
+	}
+
+	if other := IdempotencyKey(issueRequest("anima:document:2", "0x01")); other == key {
+		t.Error("two documents share an idempotency key")
+	}
+
+	if unsigned := IdempotencyKey(&IssueRequest{Attributes: map[string]*IssAttribute{"firstname": {}}}); unsigned != "" {
+		t.Errorf("unsigned request key = %s", unsigned)
+	}
+}
+
+func TestMessageIdempotencyKey(t *testing.T) {
+	request := func(signature string) *VerifyRequest {
+		return &VerifyRequest{Authorization: &SharingAuthorization{Specs: "anima:specs:sharing/authorization@1.0.0", Content: "{}", Signature: signature}}
+	}
+
+	key := MessageIdempotencyKey(request("0x01"))
+	if key == "" || MessageIdempotencyKey(request("0x01")) != key {
+		t.Errorf("key = %q, want a stable key", key)
+	}
+
+	if MessageIdempotencyKey(request("0x02")) == key {
+		t.Error("two requests share an idempotency key")
+	}
+
+	if empty := MessageIdempotencyKey(&VerifyRequest{}); empty != "" {
+		t.Errorf("empty request key = %s", empty)
+	}
+}
@@ -2,28 +2,43 @@ package protocol
 
 import (
 	"crypto/tls"
-	"fmt"
+	"sync"
 
+	"github.com/anima-protocol/anima-go/logger"
 	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/telemetry"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 )
 
-var client AnimaClient
+var (
+	client   AnimaClient
+	conn     *grpc.ClientConn
+	clientMu sync.Mutex
+)
 
 type Config struct {
 	Secure bool
+	TLS    *tls.Config
 }
 
 // Init - Initialize New Client
 func Init(config *Config, protocol *models.Protocol) error {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
 	if client == nil {
-		fmt.Printf("-> Anima Client")
-		creds := credentials.NewTLS(&tls.Config{
-			InsecureSkipVerify: true,
-		})
+		tlsConfig := config.TLS
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{
+				InsecureSkipVerify: true,
+			}
+		}
+		creds := credentials.NewTLS(tlsConfig)
 
-		opts := []grpc.DialOption{}
+		opts := []grpc.DialOption{
+			grpc.WithChainUnaryInterceptor(telemetry.UnaryClientInterceptor()),
+		}
 
 		if config.Secure {
 			opts = append(opts, grpc.WithTransportCredentials(creds))
@@ -31,14 +46,32 @@ func Init(config *Config, protocol *models.Protocol) error {
 			opts = append(opts, grpc.WithInsecure())
 		}
 
-		fmt.Printf("-> network: %v\n", protocol.Network)
+		logger.Debug("anima client connecting", logger.String("network", protocol.Network), logger.Any("secure", config.Secure))
+		opts = append(opts, protocol.DialOptions...)
 		cc, err := grpc.Dial(protocol.Network, opts...)
 		if err != nil {
-			return fmt.Errorf("could not connect to GRPC Server")
+			logger.Error("anima client connection failed", logger.String("network", protocol.Network), logger.Err(err))
+			return models.WrapError(models.ErrNetworkUnavailable, err, "could not connect to GRPC Server %s", protocol.Network)
 		}
 
+		conn = cc
 		client = NewAnimaClient(cc)
 	}
 
 	return nil
 }
+
+// Close - Close the client connection, the next call initializes a new one
+func Close() error {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+
+	err := conn.Close()
+	conn = nil
+	client = nil
+	return err
+}
@@ -0,0 +1,60 @@
+package sharing
+
+import (
+	"fmt"
+	"strings"
+)
+
+const base45Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ $%*+-./:"
+
+// base45Encode - Encode bytes following RFC 9285
+func base45Encode(src []byte) string {
+	var sb strings.Builder
+	for i := 0; i < len(src); i += 2 {
+		if i+1 < len(src) {
+			n := int(src[i])*256 + int(src[i+1])
+			sb.WriteByte(base45Alphabet[n%45])
+			sb.WriteByte(base45Alphabet[(n/45)%45])
+			sb.WriteByte(base45Alphabet[n/(45*45)])
+		} else {
+			n := int(src[i])
+			sb.WriteByte(base45Alphabet[n%45])
+			sb.WriteByte(base45Alphabet[n/45])
+		}
+	}
+	return sb.String()
+}
+
+// base45Decode - Decode string following RFC 9285
+func base45Decode(src string) ([]byte, error) {
+	if len(src)%3 == 1 {
+		return nil, fmt.Errorf("invalid base45 length: %d", len(src))
+	}
+
+	values := make([]int, len(src))
+	for i := 0; i < len(src); i++ {
+		v := strings.IndexByte(base45Alphabet, src[i])
+		if v < 0 {
+			return nil, fmt.Errorf("invalid base45 character at %d", i)
+		}
+		values[i] = v
+	}
+
+	dst := make([]byte, 0, len(src)/3*2+1)
+	for i := 0; i < len(values); i += 3 {
+		if i+2 < len(values) {
+			n := values[i] + values[i+1]*45 + values[i+2]*45*45
+			if n > 0xffff {
+				return nil, fmt.Errorf("invalid base45 chunk at %d", i)
+			}
+			dst = append(dst, byte(n>>8), byte(n))
+		} else {
+			n := values[i] + values[i+1]*45
+			if n > 0xff {
+				return nil, fmt.Errorf("invalid base45 chunk at %d", i)
+			}
+			dst = append(dst, byte(n))
+		}
+	}
+	return dst, nil
+}
@@ -0,0 +1,66 @@
+package sharing
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestBase45Vectors(t *testing.T) {
+	// RFC 9285 section 4.3 and 4.4 examples
+	for _, test := range []struct {
+		decoded string
+		encoded string
+	}{
+		{"", ""},
+		{"AB", "BB8"},
+		{"Hello!!", "%69 VD92EX0"},
+		{"base-45", "UJCLQE7W581"},
+		{"ietf!", "QED8WEX0"},
+	} {
+		if encoded := base45Encode([]byte(test.decoded)); encoded != test.encoded {
+			t.Errorf("encode(%q) = %q, want %q", test.decoded, encoded, test.encoded)
+		}
+
+		decoded, err := base45Decode(test.encoded)
+		if err != nil {
+			t.Errorf("decode(%q): %v", test.encoded, err)
+			continue
+		}
+
+		if !bytes.Equal(decoded, []byte(test.decoded)) {
+			t.Errorf("decode(%q) = %q, want %q", test.encoded, decoded, test.decoded)
+		}
+	}
+}
+
+func TestBase45RoundTrip(t *testing.T) {
+	src := make([]byte, 256)
+	for i := range src {
+		src[i] = byte(255 - i)
+	}
+
+	for n := 0; n <= len(src); n++ {
+		decoded, err := base45Decode(base45Encode(src[:n]))
+		if err != nil {
+			t.Fatalf("length %d: %v", n, err)
+		}
+
+		if !bytes.Equal(decoded, src[:n]) {
+			t.Fatalf("length %d: round trip mismatch", n)
+		}
+	}
+}
+
+func TestBase45DecodeRejectsInvalidInput(t *testing.T) {
+	for _, encoded := range []string{
+		"A",    // dangling character
+		"GGW",  // 65535 < chunk value
+		"GG",   // 255 < trailing chunk value
+		"ab8",  // lowercase is outside the alphabet
+		"BB8#", // invalid character and length
+	} {
+		if _, err := base45Decode(encoded); err == nil {
+			t.Errorf("decode(%q) succeeded", encoded)
+		}
+	}
+}
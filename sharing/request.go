@@ -0,0 +1,248 @@
+package sharing
+
+import (
+	"bytes"
+	"compress/flate"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/crypto"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/utils"
+)
+
+const (
+	/* SPECS */
+	REQUEST_SPECS     = "anima:specs:sharing/request@1.0.0"
+	REQUEST_SPECS_JCS = "anima:specs:sharing/request@1.1.0"
+
+	/* ENCODING */
+	PAYLOAD_PREFIX   = "AN1:"
+	MAX_PAYLOAD_SIZE = 2048
+	MAX_CONTENT_SIZE = 4096
+
+	/* DEEP LINK */
+	DEEPLINK_SCHEME = "anima"
+	DEEPLINK_HOST   = "share"
+	DEEPLINK_PARAM  = "r"
+)
+
+var AVAILABLE_REQUEST_SPECS = []string{REQUEST_SPECS, REQUEST_SPECS_JCS}
+
+type Request struct {
+	Specs       string               `json:"specs"`
+	Verifier    models.AnimaVerifier `json:"verifier"`
+	Attributes  []string             `json:"attributes"`
+	Nonce       string               `json:"nonce"`
+	CallbackURL string               `json:"callback_url"`
+	ExpiresAt   int64                `json:"expires_at"`
+}
+
+type signedRequest struct {
+	Content   json.RawMessage `json:"content"`
+	Signature string          `json:"signature"`
+}
+
+// NewNonce - Generate random nonce for sharing request
+func NewNonce() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Encode - Sign and encode sharing request as base45 QR payload
+func Encode(anima *models.Protocol, request *Request) (string, error) {
+	// Work on a copy, the caller request is left untouched
+	copied := *request
+	request = &copied
+
+	if request.Specs == "" {
+		request.Specs = REQUEST_SPECS
+		if anima.GetSpecsVersion() == models.SPECS_VERSION_JCS {
+			request.Specs = REQUEST_SPECS_JCS
+		}
+	}
+
+	if !utils.InArray(request.Specs, AVAILABLE_REQUEST_SPECS) || crypto.SpecsVersion(request.Specs) != anima.GetSpecsVersion() {
+		return "", fmt.Errorf("sharing request specs %s does not match specs version %s", request.Specs, anima.GetSpecsVersion())
+	}
+
+	if err := validateRequest(request); err != nil {
+		return "", err
+	}
+
+	content, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+
+	signed := signedRequest{Content: content}
+	switch anima.Chain {
+	case models.CHAIN_ETH:
+		signature, err := evm.SignSharingRequest(anima, content, anima.SigningFunc)
+		if err != nil {
+			return "", err
+		}
+
+		signed.Signature = "0x" + strings.TrimPrefix(signature, "0x")
+	default:
+		return "", models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+	}
+
+	b, err := json.Marshal(signed)
+	if err != nil {
+		return "", err
+	}
+
+	compressed := new(bytes.Buffer)
+	w, err := flate.NewWriter(compressed, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := w.Write(b); err != nil {
+		return "", err
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	payload := PAYLOAD_PREFIX + base45Encode(compressed.Bytes())
+	if len(payload) > MAX_PAYLOAD_SIZE {
+		return "", fmt.Errorf("payload too large: %d > %d", len(payload), MAX_PAYLOAD_SIZE)
+	}
+
+	return payload, nil
+}
+
+// Decode - Decode QR payload and verify verifier signature of sharing request
+func Decode(payload string) (*Request, error) {
+	if len(payload) > MAX_PAYLOAD_SIZE {
+		return nil, fmt.Errorf("payload too large: %d > %d", len(payload), MAX_PAYLOAD_SIZE)
+	}
+
+	if !strings.HasPrefix(payload, PAYLOAD_PREFIX) {
+		return nil, fmt.Errorf("invalid payload prefix")
+	}
+
+	compressed, err := base45Decode(strings.TrimPrefix(payload, PAYLOAD_PREFIX))
+	if err != nil {
+		return nil, err
+	}
+
+	r := flate.NewReader(bytes.NewReader(compressed))
+	defer r.Close()
+
+	b, err := io.ReadAll(io.LimitReader(r, MAX_CONTENT_SIZE+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) > MAX_CONTENT_SIZE {
+		return nil, fmt.Errorf("content too large: > %d", MAX_CONTENT_SIZE)
+	}
+
+	signed := signedRequest{}
+	if err := json.Unmarshal(b, &signed); err != nil {
+		return nil, err
+	}
+
+	request := Request{}
+	decoder := json.NewDecoder(bytes.NewReader(signed.Content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		return nil, err
+	}
+
+	if !utils.InArray(request.Specs, AVAILABLE_REQUEST_SPECS) {
+		return nil, fmt.Errorf("unsupported sharing request specs: %s", request.Specs)
+	}
+
+	if err := validateRequest(&request); err != nil {
+		return nil, err
+	}
+
+	switch request.Verifier.Chain {
+	case models.CHAIN_ETH:
+		valid, err := evm.VerifySharingRequest(request.Verifier.PublicAddress, crypto.SpecsVersion(request.Specs), signed.Content, signed.Signature)
+		if err != nil {
+			return nil, err
+		}
+
+		if !valid {
+			return nil, models.NewError(models.ErrBadSignature, "invalid sharing request signature")
+		}
+	default:
+		return nil, models.NewError(models.ErrUnsupportedChain, "unsupported chain")
+	}
+
+	return &request, nil
+}
+
+// DeepLink - Sign and encode sharing request as anima:// deep link
+func DeepLink(anima *models.Protocol, request *Request) (string, error) {
+	payload, err := Encode(anima, request)
+	if err != nil {
+		return "", err
+	}
+
+	link := url.URL{
+		Scheme:   DEEPLINK_SCHEME,
+		Host:     DEEPLINK_HOST,
+		RawQuery: url.Values{DEEPLINK_PARAM: []string{payload}}.Encode(),
+	}
+	return link.String(), nil
+}
+
+// ParseDeepLink - Decode anima:// deep link and verify sharing request
+func ParseDeepLink(link string) (*Request, error) {
+	if len(link) > 3*MAX_PAYLOAD_SIZE {
+		return nil, fmt.Errorf("deep link too large")
+	}
+
+	u, err := url.Parse(link)
+	if err != nil {
+		return nil, err
+	}
+
+	if u.Scheme != DEEPLINK_SCHEME || u.Host != DEEPLINK_HOST {
+		return nil, fmt.Errorf("invalid deep link: %s://%s", u.Scheme, u.Host)
+	}
+
+	return Decode(u.Query().Get(DEEPLINK_PARAM))
+}
+
+func validateRequest(request *Request) error {
+	if request.Verifier.PublicAddress == "" {
+		return fmt.Errorf("verifier public address is required")
+	}
+
+	if len(request.Attributes) == 0 {
+		return fmt.Errorf("at least one attribute must be requested")
+	}
+
+	if len(request.Nonce) < 16 {
+		return fmt.Errorf("nonce must be at least 16 characters")
+	}
+
+	callback, err := url.Parse(request.CallbackURL)
+	if err != nil || !callback.IsAbs() || callback.Host == "" {
+		return fmt.Errorf("invalid callback url: %s", request.CallbackURL)
+	}
+
+	if request.ExpiresAt <= time.Now().Unix() {
+		return models.NewError(models.ErrAuthorizationExpired, "sharing request expired")
+	}
+
+	return nil
+}
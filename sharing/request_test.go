@@ -0,0 +1,141 @@
+package sharing
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/chains/evm"
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+func newRequest(t *testing.T) (*models.Protocol, *Request) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anima := &models.Protocol{Chain: models.CHAIN_ETH, SigningFunc: evm.PrivateKeySigningFunc(key)}
+	return anima, &Request{
+		Verifier:    models.AnimaVerifier{ID: "verifier", PublicAddress: crypto.PubkeyToAddress(key.PublicKey).Hex(), Chain: models.CHAIN_ETH},
+		Attributes:  []string{"firstname"},
+		Nonce:       "0123456789abcdef",
+		CallbackURL: "https://verifier.example/callback",
+		ExpiresAt:   time.Now().Add(time.Minute).Unix(),
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	for specsVersion, specs := range map[string]string{
+		models.SPECS_VERSION_LEGACY: REQUEST_SPECS,
+		models.SPECS_VERSION_JCS:    REQUEST_SPECS_JCS,
+	} {
+		t.Run(specsVersion, func(t *testing.T) {
+			key, err := crypto.GenerateKey()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			anima := &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: specsVersion, SigningFunc: evm.PrivateKeySigningFunc(key)}
+			request := &Request{
+				Verifier:    models.AnimaVerifier{ID: "verifier", PublicAddress: crypto.PubkeyToAddress(key.PublicKey).Hex(), Chain: models.CHAIN_ETH},
+				Attributes:  []string{"firstname", "nationality"},
+				Nonce:       "0123456789abcdef",
+				CallbackURL: "https://verifier.example/callback",
+				ExpiresAt:   time.Now().Add(time.Minute).Unix(),
+			}
+
+			link, err := DeepLink(anima, request)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if request.Specs != "" {
+				t.Errorf("caller request specs set to %s", request.Specs)
+			}
+
+			decoded, err := ParseDeepLink(link)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if decoded.Specs != specs {
+				t.Errorf("specs = %s, want %s", decoded.Specs, specs)
+			}
+
+			if decoded.Verifier.PublicAddress != request.Verifier.PublicAddress || len(decoded.Attributes) != 2 {
+				t.Errorf("decoded request = %+v", decoded)
+			}
+		})
+	}
+}
+
+func TestEncodeRejectsSpecsVersionMismatch(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	anima := &models.Protocol{Chain: models.CHAIN_ETH, SpecsVersion: models.SPECS_VERSION_JCS, SigningFunc: evm.PrivateKeySigningFunc(key)}
+	request := &Request{
+		Specs:       REQUEST_SPECS,
+		Verifier:    models.AnimaVerifier{ID: "verifier", PublicAddress: crypto.PubkeyToAddress(key.PublicKey).Hex(), Chain: models.CHAIN_ETH},
+		Attributes:  []string{"firstname"},
+		Nonce:       "0123456789abcdef",
+		CallbackURL: "https://verifier.example/callback",
+		ExpiresAt:   time.Now().Add(time.Minute).Unix(),
+	}
+
+	if _, err := Encode(anima, request); err == nil {
+		t.Error("signed 1.0.0 specs with 1.1.0 canonicalization")
+	}
+}
+
+func TestDecodeRejectsOtherVerifier(t *testing.T) {
+	anima, request := newRequest(t)
+	_, other := newRequest(t)
+	request.Verifier.PublicAddress = other.Verifier.PublicAddress
+
+	payload, err := Encode(anima, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Decode(payload); !errors.Is(err, models.ErrBadSignature) {
+		t.Errorf("err = %v, want %v", err, models.ErrBadSignature)
+	}
+}
+
+func TestEncodeRejectsExpiredRequest(t *testing.T) {
+	anima, request := newRequest(t)
+	request.ExpiresAt = time.Now().Add(-time.Second).Unix()
+
+	if _, err := Encode(anima, request); !errors.Is(err, models.ErrAuthorizationExpired) {
+		t.Errorf("err = %v, want %v", err, models.ErrAuthorizationExpired)
+	}
+}
+
+func TestDecodeRejectsMalformedPayload(t *testing.T) {
+	anima, request := newRequest(t)
+	payload, err := Encode(anima, request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, malformed := range map[string]string{
+		"prefix":    "AN2:" + strings.TrimPrefix(payload, PAYLOAD_PREFIX),
+		"truncated": payload[:len(payload)-1],
+		"oversized": PAYLOAD_PREFIX + strings.Repeat("0", MAX_PAYLOAD_SIZE),
+	} {
+		if _, err := Decode(malformed); err == nil {
+			t.Errorf("%s: decoded a malformed payload", name)
+		}
+	}
+
+	if _, err := ParseDeepLink("https://share?r=" + payload); err == nil {
+		t.Error("parsed a deep link with another scheme")
+	}
+}
@@ -0,0 +1,150 @@
+package telemetry
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/metric/unit"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	INSTRUMENTATION_NAME = "github.com/anima-protocol/anima-go"
+
+	/* SPANS */
+	SPAN_SIGN_ISSUING    = "anima.SignIssuing"
+	SPAN_SIGN_PROOF      = "anima.SignProof"
+	SPAN_SIGN_CREDENTIAL = "anima.SignCredential"
+
+	/* METRICS */
+	METRIC_PROTOCOL_CALLS    = "anima.protocol.calls"
+	METRIC_PROTOCOL_ERRORS   = "anima.protocol.errors"
+	METRIC_PROTOCOL_DURATION = "anima.protocol.duration"
+	METRIC_SIGNING_DURATION  = "anima.signing.duration"
+	METRIC_ATTRIBUTES_SIGNED = "anima.attributes.signed"
+)
+
+var (
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+	current        *instruments
+	providerMu     sync.Mutex
+)
+
+// SetTracerProvider - Trace with tp instead of the otel global provider, a no-op unless configured
+func SetTracerProvider(tp trace.TracerProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	tracerProvider = tp
+}
+
+// SetMeterProvider - Record metrics with mp instead of the otel global provider, a no-op unless configured
+func SetMeterProvider(mp metric.MeterProvider) {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	meterProvider = mp
+	current = nil
+}
+
+func tracer() trace.Tracer {
+	providerMu.Lock()
+	tp := tracerProvider
+	providerMu.Unlock()
+
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(INSTRUMENTATION_NAME)
+}
+
+type instruments struct {
+	protocolCalls    syncint64.Counter
+	protocolErrors   syncint64.Counter
+	protocolDuration syncfloat64.Histogram
+	signingDuration  syncfloat64.Histogram
+	attributesSigned syncint64.Counter
+}
+
+func getInstruments() *instruments {
+	providerMu.Lock()
+	defer providerMu.Unlock()
+
+	if current != nil {
+		return current
+	}
+
+	mp := meterProvider
+	if mp == nil {
+		mp = global.MeterProvider()
+	}
+
+	current = newInstruments(mp.Meter(INSTRUMENTATION_NAME))
+	return current
+}
+
+// newInstruments - Create the SDK instruments, falling back to no-ops on provider errors
+func newInstruments(meter metric.Meter) *instruments {
+	noop := metric.NewNoopMeter()
+	i := &instruments{}
+	var err error
+
+	if i.protocolCalls, err = meter.SyncInt64().Counter(METRIC_PROTOCOL_CALLS, instrument.WithDescription("Anima Protocol calls by method and code")); err != nil {
+		i.protocolCalls, _ = noop.SyncInt64().Counter(METRIC_PROTOCOL_CALLS)
+	}
+	if i.protocolErrors, err = meter.SyncInt64().Counter(METRIC_PROTOCOL_ERRORS, instrument.WithDescription("Failed Anima Protocol calls by method and code")); err != nil {
+		i.protocolErrors, _ = noop.SyncInt64().Counter(METRIC_PROTOCOL_ERRORS)
+	}
+	if i.protocolDuration, err = meter.SyncFloat64().Histogram(METRIC_PROTOCOL_DURATION, instrument.WithUnit(unit.Milliseconds), instrument.WithDescription("Anima Protocol call latency")); err != nil {
+		i.protocolDuration, _ = noop.SyncFloat64().Histogram(METRIC_PROTOCOL_DURATION)
+	}
+	if i.signingDuration, err = meter.SyncFloat64().Histogram(METRIC_SIGNING_DURATION, instrument.WithUnit(unit.Milliseconds), instrument.WithDescription("Signing latency by operation")); err != nil {
+		i.signingDuration, _ = noop.SyncFloat64().Histogram(METRIC_SIGNING_DURATION)
+	}
+	if i.attributesSigned, err = meter.SyncInt64().Counter(METRIC_ATTRIBUTES_SIGNED, instrument.WithUnit(unit.Dimensionless), instrument.WithDescription("Attribute credentials signed")); err != nil {
+		i.attributesSigned, _ = noop.SyncInt64().Counter(METRIC_ATTRIBUTES_SIGNED)
+	}
+
+	return i
+}
+
+// StartSpan - Start an internal span of the SDK
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan - End span, marking it failed with the kind of err when set
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		kind := models.ErrorKind(err)
+		span.SetAttributes(attribute.String("error.type", kind))
+		span.SetStatus(codes.Error, kind)
+	}
+	span.End()
+}
+
+// RecordSigning - Record the latency of a signing operation started at start
+func RecordSigning(ctx context.Context, operation string, start time.Time, err error) {
+	getInstruments().signingDuration.Record(ctx, milliseconds(time.Since(start)),
+		attribute.String("operation", operation),
+		attribute.Bool("error", err != nil),
+	)
+}
+
+// RecordAttributesSigned - Count attribute credentials signed for a document specs
+func RecordAttributesSigned(ctx context.Context, count int, documentSpecs string) {
+	getInstruments().attributesSigned.Add(ctx, int64(count), attribute.String("document_specs", documentSpecs))
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
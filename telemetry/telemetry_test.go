@@ -0,0 +1,325 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncfloat64"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	grpccodes "google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+/* TRACES */
+
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (tr *recordingTracer) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return tr
+}
+
+func (tr *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	config := trace.NewSpanStartConfig(opts...)
+	span := &recordingSpan{
+		Span:  trace.SpanFromContext(context.Background()),
+		name:  name,
+		kind:  config.SpanKind(),
+		attrs: map[attribute.Key]attribute.Value{},
+	}
+	span.SetAttributes(config.Attributes()...)
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	tr.spans = append(tr.spans, span)
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	trace.Span
+	name        string
+	kind        trace.SpanKind
+	attrs       map[attribute.Key]attribute.Value
+	status      codes.Code
+	description string
+	ended       bool
+}
+
+func (s *recordingSpan) SpanContext() trace.SpanContext {
+	return trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    trace.TraceID{1},
+		SpanID:     trace.SpanID{2},
+		TraceFlags: trace.FlagsSampled,
+	})
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+func (s *recordingSpan) SetStatus(code codes.Code, description string) {
+	s.status, s.description = code, description
+}
+
+func (s *recordingSpan) End(...trace.SpanEndOption) {
+	s.ended = true
+}
+
+func setTracer(t *testing.T) *recordingTracer {
+	t.Helper()
+	tr := &recordingTracer{}
+	SetTracerProvider(tr)
+	t.Cleanup(func() { SetTracerProvider(nil) })
+	return tr
+}
+
+/* METRICS */
+
+type measurement struct {
+	value float64
+	attrs attribute.Set
+}
+
+type recordingMeter struct {
+	metric.Meter
+	mu           sync.Mutex
+	measurements map[string][]measurement
+	fail         bool
+}
+
+type recordingMeterProvider struct {
+	meter *recordingMeter
+}
+
+func (p recordingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return p.meter
+}
+
+func (m *recordingMeter) SyncInt64() syncint64.InstrumentProvider {
+	return recordingInt64{InstrumentProvider: m.Meter.SyncInt64(), meter: m}
+}
+
+func (m *recordingMeter) SyncFloat64() syncfloat64.InstrumentProvider {
+	return recordingFloat64{InstrumentProvider: m.Meter.SyncFloat64(), meter: m}
+}
+
+func (m *recordingMeter) record(name string, value float64, attrs []attribute.KeyValue) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.measurements[name] = append(m.measurements[name], measurement{value: value, attrs: attribute.NewSet(attrs...)})
+}
+
+func (m *recordingMeter) get(name string) []measurement {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.measurements[name]
+}
+
+type recordingInt64 struct {
+	syncint64.InstrumentProvider
+	meter *recordingMeter
+}
+
+func (p recordingInt64) Counter(name string, opts ...instrument.Option) (syncint64.Counter, error) {
+	if p.meter.fail {
+		return nil, errors.New("instrument unavailable")
+	}
+
+	counter, _ := p.InstrumentProvider.Counter(name, opts...)
+	return recordingCounter{Counter: counter, name: name, meter: p.meter}, nil
+}
+
+type recordingCounter struct {
+	syncint64.Counter
+	name  string
+	meter *recordingMeter
+}
+
+func (c recordingCounter) Add(_ context.Context, incr int64, attrs ...attribute.KeyValue) {
+	c.meter.record(c.name, float64(incr), attrs)
+}
+
+type recordingFloat64 struct {
+	syncfloat64.InstrumentProvider
+	meter *recordingMeter
+}
+
+func (p recordingFloat64) Histogram(name string, opts ...instrument.Option) (syncfloat64.Histogram, error) {
+	if p.meter.fail {
+		return nil, errors.New("instrument unavailable")
+	}
+
+	histogram, _ := p.InstrumentProvider.Histogram(name, opts...)
+	return recordingHistogram{Histogram: histogram, name: name, meter: p.meter}, nil
+}
+
+type recordingHistogram struct {
+	syncfloat64.Histogram
+	name  string
+	meter *recordingMeter
+}
+
+func (h recordingHistogram) Record(_ context.Context, value float64, attrs ...attribute.KeyValue) {
+	h.meter.record(h.name, value, attrs)
+}
+
+func setMeter(t *testing.T) *recordingMeter {
+	t.Helper()
+	m := &recordingMeter{Meter: metric.NewNoopMeter(), measurements: map[string][]measurement{}}
+	SetMeterProvider(recordingMeterProvider{m})
+	t.Cleanup(func() { SetMeterProvider(nil) })
+	return m
+}
+
+func attr(set attribute.Set, key string) string {
+	value, _ := set.Value(attribute.Key(key))
+	return value.Emit()
+}
+
+func TestEndSpanRecordsErrorKindOnly(t *testing.T) {
+	tr := setTracer(t)
+
+	_, span := StartSpan(context.Background(), SPAN_SIGN_ISSUING, attribute.String("document_specs", models.DOCUMENT_SPECS_PASSPORT))
+	EndSpan(span, models.NewError(models.ErrInvalidRequest, "attribute lastname: Buterin is too long"))
+
+	recorded := tr.spans[0]
+	if recorded.name != SPAN_SIGN_ISSUING || !recorded.ended || recorded.attrs["document_specs"].AsString() != models.DOCUMENT_SPECS_PASSPORT {
+		t.Errorf("span = %+v", recorded)
+	}
+
+	if recorded.status != codes.Error || recorded.description != models.ErrInvalidRequest.Error() || recorded.attrs["error.type"].AsString() != models.ErrInvalidRequest.Error() {
+		t.Errorf("status = %s %q, error.type = %s", recorded.status, recorded.description, recorded.attrs["error.type"].Emit())
+	}
+
+	_, span = StartSpan(context.Background(), SPAN_SIGN_PROOF)
+	EndSpan(span, nil)
+	if recorded := tr.spans[1]; !recorded.ended || recorded.status != codes.Unset {
+		t.Errorf("successful span = %+v", recorded)
+	}
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	tr := setTracer(t)
+	m := setMeter(t)
+
+	propagator := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(propagator) })
+
+	cc, err := grpc.Dial("passthrough:///anima.test:443", grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cc.Close()
+
+	interceptor := UnaryClientInterceptor()
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "signature", "0x1234")
+
+	var outgoing metadata.MD
+	invoker := func(err error) grpc.UnaryInvoker {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			outgoing, _ = metadata.FromOutgoingContext(ctx)
+			return err
+		}
+	}
+
+	if err := interceptor(ctx, "/anima.Anima/Issue", nil, nil, cc, invoker(nil)); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(outgoing.Get("traceparent")) != 1 || len(outgoing.Get("signature")) != 1 {
+		t.Errorf("outgoing metadata = %v, want the trace context and the caller metadata", outgoing)
+	}
+
+	if md, _ := metadata.FromOutgoingContext(ctx); len(md.Get("traceparent")) != 0 {
+		t.Error("caller metadata changed")
+	}
+
+	unavailable := status.Error(grpccodes.Unavailable, "dial tcp 10.0.0.1:443: connection refused")
+	if err := interceptor(ctx, "/anima.Anima/Verify", nil, nil, cc, invoker(unavailable)); err != unavailable {
+		t.Errorf("err = %v, want the invoker error", err)
+	}
+
+	issue, verify := tr.spans[0], tr.spans[1]
+	if issue.name != "/anima.Anima/Issue" || issue.kind != trace.SpanKindClient || issue.attrs["net.peer.name"].AsString() != "passthrough:///anima.test:443" || issue.status != codes.Unset {
+		t.Errorf("issue span = %+v", issue)
+	}
+
+	if verify.status != codes.Error || verify.description != "Unavailable" || strings.Contains(verify.attrs["error.type"].Emit(), "10.0.0.1") {
+		t.Errorf("verify span = %+v", verify)
+	}
+
+	calls := m.get(METRIC_PROTOCOL_CALLS)
+	if len(calls) != 2 || attr(calls[0].attrs, "rpc.grpc.status_code") != "OK" || attr(calls[1].attrs, "rpc.method") != "/anima.Anima/Verify" {
+		t.Errorf("calls = %+v", calls)
+	}
+
+	errs := m.get(METRIC_PROTOCOL_ERRORS)
+	if len(errs) != 1 || attr(errs[0].attrs, "rpc.grpc.status_code") != "Unavailable" {
+		t.Errorf("errors = %+v", errs)
+	}
+
+	if durations := m.get(METRIC_PROTOCOL_DURATION); len(durations) != 2 {
+		t.Errorf("durations = %+v", durations)
+	}
+}
+
+func TestRecordSigning(t *testing.T) {
+	m := setMeter(t)
+
+	RecordSigning(context.Background(), "issuing", time.Now().Add(-1500*time.Microsecond), errors.New("signer unavailable"))
+	RecordAttributesSigned(context.Background(), 3, models.DOCUMENT_SPECS_PASSPORT)
+
+	signing := m.get(METRIC_SIGNING_DURATION)
+	if len(signing) != 1 || signing[0].value < 1.5 || attr(signing[0].attrs, "operation") != "issuing" || attr(signing[0].attrs, "error") != "true" {
+		t.Errorf("signing = %+v", signing)
+	}
+
+	signed := m.get(METRIC_ATTRIBUTES_SIGNED)
+	if len(signed) != 1 || signed[0].value != 3 || attr(signed[0].attrs, "document_specs") != models.DOCUMENT_SPECS_PASSPORT {
+		t.Errorf("attributes signed = %+v", signed)
+	}
+}
+
+func TestSetMeterProviderReplacesInstruments(t *testing.T) {
+	first := setMeter(t)
+	RecordAttributesSigned(context.Background(), 1, models.DOCUMENT_SPECS_PASSPORT)
+
+	second := setMeter(t)
+	RecordAttributesSigned(context.Background(), 2, models.DOCUMENT_SPECS_PASSPORT)
+
+	if len(first.get(METRIC_ATTRIBUTES_SIGNED)) != 1 || len(second.get(METRIC_ATTRIBUTES_SIGNED)) != 1 {
+		t.Errorf("measurements = %v then %v", first.measurements, second.measurements)
+	}
+}
+
+func TestInstrumentErrorsFallBackToNoop(t *testing.T) {
+	m := &recordingMeter{Meter: metric.NewNoopMeter(), measurements: map[string][]measurement{}, fail: true}
+	SetMeterProvider(recordingMeterProvider{m})
+	t.Cleanup(func() { SetMeterProvider(nil) })
+
+	// Nothing is recorded, but nothing panics on nil instruments either
+	RecordSigning(context.Background(), "issuing", time.Now(), nil)
+	RecordAttributesSigned(context.Background(), 1, models.DOCUMENT_SPECS_PASSPORT)
+	if len(m.measurements) != 0 {
+		t.Errorf("measurements = %v", m.measurements)
+	}
+}
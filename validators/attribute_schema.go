@@ -0,0 +1,230 @@
+package validators
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+	"github.com/anima-protocol/anima-go/utils"
+)
+
+// AttributeSchema - Accepted formats and normalization of an attribute type
+type AttributeSchema struct {
+	Type string
+	// Formats - Accepted formats, the first one is used when none is given
+	Formats   []string
+	Normalize func(value string) (string, error)
+}
+
+var (
+	attributeSchemas = map[string]*AttributeSchema{
+		models.ATTRIBUTE_TYPE_STRING:  {Type: models.ATTRIBUTE_TYPE_STRING, Formats: []string{"text"}, Normalize: normalizeString},
+		models.ATTRIBUTE_TYPE_INTEGER: {Type: models.ATTRIBUTE_TYPE_INTEGER, Formats: []string{"decimal"}, Normalize: normalizeInteger},
+		models.ATTRIBUTE_TYPE_BOOLEAN: {Type: models.ATTRIBUTE_TYPE_BOOLEAN, Formats: []string{"boolean"}, Normalize: normalizeBoolean},
+		models.ATTRIBUTE_TYPE_DATE:    {Type: models.ATTRIBUTE_TYPE_DATE, Formats: []string{"iso-8601"}, Normalize: normalizeDate},
+		models.ATTRIBUTE_TYPE_COUNTRY: {Type: models.ATTRIBUTE_TYPE_COUNTRY, Formats: []string{"iso-3166-1-alpha-2"}, Normalize: normalizeCountry},
+		models.ATTRIBUTE_TYPE_EMAIL:   {Type: models.ATTRIBUTE_TYPE_EMAIL, Formats: []string{"email"}, Normalize: normalizeEmail},
+		models.ATTRIBUTE_TYPE_PHONE:   {Type: models.ATTRIBUTE_TYPE_PHONE, Formats: []string{"e164"}, Normalize: normalizePhone},
+		models.ATTRIBUTE_TYPE_FILE:    {Type: models.ATTRIBUTE_TYPE_FILE, Formats: []string{"image/jpeg", "image/png", "image/webp", "application/pdf"}, Normalize: normalizeFileHash},
+	}
+	attributeSchemasMu sync.RWMutex
+)
+
+// RegisterAttributeSchema - Add or replace an attribute type schema, safe to call while requests are validated
+func RegisterAttributeSchema(schema *AttributeSchema) {
+	attributeSchemasMu.Lock()
+	defer attributeSchemasMu.Unlock()
+	attributeSchemas[schema.Type] = schema
+}
+
+// GetAttributeSchema - Registered schema of an attribute type
+func GetAttributeSchema(attrType string) (*AttributeSchema, bool) {
+	attributeSchemasMu.RLock()
+	defer attributeSchemasMu.RUnlock()
+	schema, ok := attributeSchemas[attrType]
+	return schema, ok
+}
+
+// ValidateAttributes - Validate every document attribute of an IssueRequest without changing it
+//
+// Used under the legacy specs version, whose attribute ids hash the values as given.
+func ValidateAttributes(request *protocol.IssueRequest) error {
+	return validateAttributes(request, false)
+}
+
+// NormalizeAttributes - Validate and normalize every document attribute of an IssueRequest, empty formats get their type default
+func NormalizeAttributes(request *protocol.IssueRequest) error {
+	return validateAttributes(request, true)
+}
+
+func validateAttributes(request *protocol.IssueRequest, normalize bool) error {
+	errs := FieldErrors{}
+	if request.Document == nil {
+		errs.add("document", "is required")
+		return errs
+	}
+
+	names := make([]string, 0, len(request.Document.Attributes))
+	for name := range request.Document.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := fmt.Sprintf("document.attributes.%s.content", name)
+		attribute := request.Document.Attributes[name]
+		if attribute == nil || attribute.Content == nil {
+			errs.add(path, "is required")
+			continue
+		}
+
+		value, ok := validateAttributeContent(path, attribute.Content, normalize, &errs)
+		if !ok || !normalize {
+			continue
+		}
+		attribute.Content.Value = value
+
+		if attr, ok := request.Attributes[name]; ok && attr != nil && attribute.Content.Type != models.ATTRIBUTE_TYPE_FILE {
+			attr.Value = []byte(value)
+		}
+	}
+
+	for name := range request.Attributes {
+		if _, ok := request.Document.Attributes[name]; !ok {
+			errs.add(fmt.Sprintf("attributes.%s", name), "is not a document attribute")
+		}
+	}
+
+	return errs.err()
+}
+
+func validateAttributeContent(path string, content *protocol.IssDocumentAttributeContent, normalize bool, errs *FieldErrors) (string, bool) {
+	// Types without a registered schema are issuer defined and signed as given
+	schema, ok := GetAttributeSchema(content.Type)
+	if !ok {
+		return content.Value, true
+	}
+
+	if content.Format == "" && normalize && schema.Type != models.ATTRIBUTE_TYPE_FILE {
+		content.Format = schema.Formats[0]
+	}
+
+	if (content.Format != "" || normalize) && !utils.InArray(content.Format, schema.Formats) {
+		errs.add(path+".format", "format %q is not allowed for %s, expected one of %s", content.Format, schema.Type, strings.Join(schema.Formats, ", "))
+		return "", false
+	}
+
+	value, err := schema.Normalize(content.Value)
+	if err != nil {
+		errs.add(path+".value", "%v", err)
+		return "", false
+	}
+
+	return value, true
+}
+
+var (
+	phoneRegexp = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+	phoneStrip  = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+)
+
+func normalizeString(value string) (string, error) {
+	if !utf8.ValidString(value) {
+		return "", fmt.Errorf("invalid utf-8 string")
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", fmt.Errorf("is empty")
+	}
+	return value, nil
+}
+
+func normalizeInteger(value string) (string, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return "", fmt.Errorf("invalid integer %q", value)
+	}
+	return strconv.FormatInt(n, 10), nil
+}
+
+func normalizeBoolean(value string) (string, error) {
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("invalid boolean %q", value)
+	}
+	return strconv.FormatBool(b), nil
+}
+
+func normalizeDate(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range []string{"2006-01-02", "20060102", time.RFC3339} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", fmt.Errorf("invalid ISO-8601 date %q", value)
+}
+
+func normalizeCountry(value string) (string, error) {
+	code := strings.ToUpper(strings.TrimSpace(value))
+	if !utils.InArray(code, countryCodes) {
+		return "", fmt.Errorf("invalid ISO-3166 country code %q", value)
+	}
+	return code, nil
+}
+
+func normalizeEmail(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	address, err := mail.ParseAddress(value)
+	if err != nil || address.Address != value {
+		return "", fmt.Errorf("invalid email %q", value)
+	}
+
+	at := strings.LastIndex(value, "@")
+	return value[:at] + strings.ToLower(value[at:]), nil
+}
+
+func normalizePhone(value string) (string, error) {
+	phone := phoneStrip.Replace(strings.TrimSpace(value))
+	if !phoneRegexp.MatchString(phone) {
+		return "", fmt.Errorf("invalid E.164 phone number %q", value)
+	}
+	return phone, nil
+}
+
+func normalizeFileHash(value string) (string, error) {
+	hash := strings.ToLower(strings.TrimSpace(value))
+	if b, err := hex.DecodeString(hash); err != nil || len(b) != 32 {
+		return "", fmt.Errorf("invalid sha256 file hash %q", value)
+	}
+	return hash, nil
+}
+
+var countryCodes = []string{
+	"AD", "AE", "AF", "AG", "AI", "AL", "AM", "AO", "AQ", "AR", "AS", "AT", "AU", "AW", "AX", "AZ",
+	"BA", "BB", "BD", "BE", "BF", "BG", "BH", "BI", "BJ", "BL", "BM", "BN", "BO", "BQ", "BR", "BS",
+	"BT", "BV", "BW", "BY", "BZ", "CA", "CC", "CD", "CF", "CG", "CH", "CI", "CK", "CL", "CM", "CN",
+	"CO", "CR", "CU", "CV", "CW", "CX", "CY", "CZ", "DE", "DJ", "DK", "DM", "DO", "DZ", "EC", "EE",
+	"EG", "EH", "ER", "ES", "ET", "FI", "FJ", "FK", "FM", "FO", "FR", "GA", "GB", "GD", "GE", "GF",
+	"GG", "GH", "GI", "GL", "GM", "GN", "GP", "GQ", "GR", "GS", "GT", "GU", "GW", "GY", "HK", "HM",
+	"HN", "HR", "HT", "HU", "ID", "IE", "IL", "IM", "IN", "IO", "IQ", "IR", "IS", "IT", "JE", "JM",
+	"JO", "JP", "KE", "KG", "KH", "KI", "KM", "KN", "KP", "KR", "KW", "KY", "KZ", "LA", "LB", "LC",
+	"LI", "LK", "LR", "LS", "LT", "LU", "LV", "LY", "MA", "MC", "MD", "ME", "MF", "MG", "MH", "MK",
+	"ML", "MM", "MN", "MO", "MP", "MQ", "MR", "MS", "MT", "MU", "MV", "MW", "MX", "MY", "MZ", "NA",
+	"NC", "NE", "NF", "NG", "NI", "NL", "NO", "NP", "NR", "NU", "NZ", "OM", "PA", "PE", "PF", "PG",
+	"PH", "PK", "PL", "PM", "PN", "PR", "PS", "PT", "PW", "PY", "QA", "RE", "RO", "RS", "RU", "RW",
+	"SA", "SB", "SC", "SD", "SE", "SG", "SH", "SI", "SJ", "SK", "SL", "SM", "SN", "SO", "SR", "SS",
+	"ST", "SV", "SX", "SY", "SZ", "TC", "TD", "TF", "TG", "TH", "TJ", "TK", "TL", "TM", "TN", "TO",
+	"TR", "TT", "TV", "TW", "TZ", "UA", "UG", "UM", "US", "UY", "UZ", "VA", "VC", "VE", "VG", "VI",
+	"VN", "VU", "WF", "WS", "YE", "YT", "ZA", "ZM", "ZW",
+}
@@ -0,0 +1,199 @@
+package validators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+const FILE_HASH = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+// attributeRequest - IssueRequest with a single document attribute named attr
+func attributeRequest(attrType string, format string, value string) *protocol.IssueRequest {
+	return &protocol.IssueRequest{
+		Document: &protocol.IssDocument{
+			Attributes: map[string]*protocol.IssDocumentAttribute{
+				"attr": {Content: &protocol.IssDocumentAttributeContent{Type: attrType, Format: format, Value: value, Name: "attr"}},
+			},
+		},
+		Attributes: map[string]*protocol.IssAttribute{
+			"attr": {Value: []byte(value)},
+		},
+	}
+}
+
+// fieldErrorPaths - Paths of the FieldErrors in err
+func fieldErrorPaths(t *testing.T, err error) []string {
+	t.Helper()
+	var errs FieldErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("err = %v, want FieldErrors", err)
+	}
+
+	if !errors.Is(err, models.ErrInvalidRequest) {
+		t.Errorf("err = %v, want %v", err, models.ErrInvalidRequest)
+	}
+
+	paths := make([]string, 0, len(errs))
+	for _, e := range errs {
+		paths = append(paths, e.Path)
+	}
+	return paths
+}
+
+func TestNormalizeAttributes(t *testing.T) {
+	for _, test := range []struct {
+		attrType   string
+		format     string
+		value      string
+		normalized string
+	}{
+		{models.ATTRIBUTE_TYPE_STRING, "", "  Jane ", "Jane"},
+		{models.ATTRIBUTE_TYPE_INTEGER, "", " 042", "42"},
+		{models.ATTRIBUTE_TYPE_INTEGER, "decimal", "-7", "-7"},
+		{models.ATTRIBUTE_TYPE_BOOLEAN, "", "TRUE", "true"},
+		{models.ATTRIBUTE_TYPE_BOOLEAN, "", "0", "false"},
+		{models.ATTRIBUTE_TYPE_DATE, "", "1990-04-12", "1990-04-12"},
+		{models.ATTRIBUTE_TYPE_DATE, "", "19900412", "1990-04-12"},
+		{models.ATTRIBUTE_TYPE_DATE, "iso-8601", "1990-04-12T23:30:00Z", "1990-04-12"},
+		{models.ATTRIBUTE_TYPE_COUNTRY, "", " fr ", "FR"},
+		{models.ATTRIBUTE_TYPE_EMAIL, "", "Jane.Doe@Example.COM", "Jane.Doe@example.com"},
+		{models.ATTRIBUTE_TYPE_PHONE, "", "+33 6 12-34.56(78)", "+33612345678"},
+		{models.ATTRIBUTE_TYPE_FILE, "image/png", strings.ToUpper(FILE_HASH), FILE_HASH},
+		{models.ATTRIBUTE_TYPE_FILE, "application/pdf", FILE_HASH, FILE_HASH},
+	} {
+		t.Run(fmt.Sprintf("%s/%s", test.attrType, test.value), func(t *testing.T) {
+			request := attributeRequest(test.attrType, test.format, test.value)
+			if err := NormalizeAttributes(request); err != nil {
+				t.Fatal(err)
+			}
+
+			content := request.Document.Attributes["attr"].Content
+			if content.Value != test.normalized {
+				t.Errorf("value = %q, want %q", content.Value, test.normalized)
+			}
+
+			if content.Format == "" && test.attrType != models.ATTRIBUTE_TYPE_FILE {
+				t.Error("default format not set")
+			}
+
+			// File attribute values hold the file, only its content is the hash
+			want := test.normalized
+			if test.attrType == models.ATTRIBUTE_TYPE_FILE {
+				want = test.value
+			}
+
+			if value := string(request.Attributes["attr"].Value); value != want {
+				t.Errorf("attribute value = %q, want %q", value, want)
+			}
+		})
+	}
+}
+
+func TestNormalizeAttributesRejectsInvalidValues(t *testing.T) {
+	for _, test := range []struct {
+		attrType string
+		format   string
+		value    string
+		path     string
+	}{
+		{models.ATTRIBUTE_TYPE_STRING, "", "   ", "value"},
+		{models.ATTRIBUTE_TYPE_STRING, "", "\xff", "value"},
+		{models.ATTRIBUTE_TYPE_INTEGER, "", "4.2", "value"},
+		{models.ATTRIBUTE_TYPE_BOOLEAN, "", "yes", "value"},
+		{models.ATTRIBUTE_TYPE_DATE, "", "12/04/1990", "value"},
+		{models.ATTRIBUTE_TYPE_DATE, "", "1990-13-01", "value"},
+		{models.ATTRIBUTE_TYPE_DATE, "unix", "1990-04-12", "format"},
+		{models.ATTRIBUTE_TYPE_COUNTRY, "", "XX", "value"},
+		{models.ATTRIBUTE_TYPE_COUNTRY, "", "FRA", "value"},
+		{models.ATTRIBUTE_TYPE_EMAIL, "", "jane", "value"},
+		{models.ATTRIBUTE_TYPE_EMAIL, "", "Jane <jane@example.com>", "value"},
+		{models.ATTRIBUTE_TYPE_PHONE, "", "0612345678", "value"},
+		{models.ATTRIBUTE_TYPE_PHONE, "", "+0612345678", "value"},
+		{models.ATTRIBUTE_TYPE_FILE, "image/png", "abc", "value"},
+		{models.ATTRIBUTE_TYPE_FILE, "text/plain", FILE_HASH, "format"},
+		{models.ATTRIBUTE_TYPE_FILE, "", FILE_HASH, "format"},
+	} {
+		t.Run(fmt.Sprintf("%s/%s", test.attrType, test.value), func(t *testing.T) {
+			err := NormalizeAttributes(attributeRequest(test.attrType, test.format, test.value))
+			paths := fieldErrorPaths(t, err)
+			if want := "document.attributes.attr.content." + test.path; len(paths) != 1 || paths[0] != want {
+				t.Errorf("paths = %v, want [%s]", paths, want)
+			}
+		})
+	}
+}
+
+func TestValidateAttributesKeepsValues(t *testing.T) {
+	request := attributeRequest(models.ATTRIBUTE_TYPE_COUNTRY, "", " fr ")
+	if err := ValidateAttributes(request); err != nil {
+		t.Fatal(err)
+	}
+
+	content := request.Document.Attributes["attr"].Content
+	if content.Value != " fr " || content.Format != "" {
+		t.Errorf("content changed to %q in format %q", content.Value, content.Format)
+	}
+
+	paths := fieldErrorPaths(t, ValidateAttributes(attributeRequest(models.ATTRIBUTE_TYPE_COUNTRY, "", "XX")))
+	if len(paths) != 1 || paths[0] != "document.attributes.attr.content.value" {
+		t.Errorf("paths = %v", paths)
+	}
+}
+
+func TestValidateAttributesFieldPaths(t *testing.T) {
+	request := attributeRequest(models.ATTRIBUTE_TYPE_DATE, "", "not a date")
+	request.Document.Attributes["empty"] = &protocol.IssDocumentAttribute{}
+	request.Attributes["unknown"] = &protocol.IssAttribute{}
+
+	paths := fieldErrorPaths(t, NormalizeAttributes(request))
+	want := []string{"document.attributes.attr.content.value", "document.attributes.empty.content", "attributes.unknown"}
+	if strings.Join(paths, ",") != strings.Join(want, ",") {
+		t.Errorf("paths = %v, want %v", paths, want)
+	}
+}
+
+func TestUnregisteredAttributeTypeIsSignedAsGiven(t *testing.T) {
+	request := attributeRequest("issuer_score", "stars", " 4/5 ")
+	if err := NormalizeAttributes(request); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := request.Document.Attributes["attr"].Content.Value; value != " 4/5 " {
+		t.Errorf("value = %q", value)
+	}
+}
+
+func TestRegisterAttributeSchemaWhileValidating(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func(i int) {
+			defer wg.Done()
+			RegisterAttributeSchema(&AttributeSchema{
+				Type:      fmt.Sprintf("test_schema_%d", i),
+				Formats:   []string{"upper"},
+				Normalize: func(value string) (string, error) { return strings.ToUpper(value), nil },
+			})
+		}(i)
+		go func(i int) {
+			defer wg.Done()
+			NormalizeAttributes(attributeRequest(fmt.Sprintf("test_schema_%d", i), "", "value"))
+		}(i)
+	}
+	wg.Wait()
+
+	request := attributeRequest("test_schema_0", "", "value")
+	if err := NormalizeAttributes(request); err != nil {
+		t.Fatal(err)
+	}
+
+	if value := request.Document.Attributes["attr"].Content.Value; value != "VALUE" {
+		t.Errorf("value = %q, want registered schema normalization", value)
+	}
+}
@@ -0,0 +1,216 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+const YEAR = 365 * 24 * time.Hour
+
+// DocumentAttribute - Attribute a document specs can prove
+type DocumentAttribute struct {
+	Type     string `json:"type"`
+	Required bool   `json:"required"`
+}
+
+// DocumentSpec - Attributes and maximum validity of a document type
+type DocumentSpec struct {
+	Specs       string                        `json:"specs"`
+	Attributes  map[string]*DocumentAttribute `json:"attributes"`
+	MaxValidity time.Duration                 `json:"max_validity"`
+}
+
+func requiredAttribute(attrType string) *DocumentAttribute {
+	return &DocumentAttribute{Type: attrType, Required: true}
+}
+
+func optionalAttribute(attrType string) *DocumentAttribute {
+	return &DocumentAttribute{Type: attrType}
+}
+
+var (
+	documentSpecs = map[string]*DocumentSpec{
+		models.DOCUMENT_SPECS_PASSPORT: {
+			Specs:       models.DOCUMENT_SPECS_PASSPORT,
+			MaxValidity: 10 * YEAR,
+			Attributes: map[string]*DocumentAttribute{
+				"firstname":       requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"lastname":        requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"birth_date":      requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"nationality":     requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"document_number": requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"issuing_country": requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"expiration_date": requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"middle_name":     optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"birth_place":     optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"sex":             optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"passport_page":   optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+				"selfie":          optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+			},
+		},
+		models.DOCUMENT_SPECS_NATIONAL_ID: {
+			Specs:       models.DOCUMENT_SPECS_NATIONAL_ID,
+			MaxValidity: 10 * YEAR,
+			Attributes: map[string]*DocumentAttribute{
+				"firstname":       requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"lastname":        requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"birth_date":      requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"nationality":     requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"document_number": requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"issuing_country": requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"expiration_date": optionalAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"birth_place":     optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"sex":             optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"address":         optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"id_front":        optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+				"id_back":         optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+				"selfie":          optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+			},
+		},
+		models.DOCUMENT_SPECS_DRIVER_LICENSE: {
+			Specs:       models.DOCUMENT_SPECS_DRIVER_LICENSE,
+			MaxValidity: 15 * YEAR,
+			Attributes: map[string]*DocumentAttribute{
+				"firstname":       requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"lastname":        requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"birth_date":      requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"document_number": requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"issuing_country": requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"expiration_date": requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"categories":      optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"address":         optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"license_front":   optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+				"license_back":    optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+			},
+		},
+		models.DOCUMENT_SPECS_PROOF_OF_ADDRESS: {
+			Specs:       models.DOCUMENT_SPECS_PROOF_OF_ADDRESS,
+			MaxValidity: 1 * YEAR,
+			Attributes: map[string]*DocumentAttribute{
+				"firstname":   requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"lastname":    requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"address":     requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"country":     requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"issued_date": requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"city":        optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"postal_code": optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"document":    optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+			},
+		},
+		models.DOCUMENT_SPECS_COMPANY_REGISTRATION: {
+			Specs:       models.DOCUMENT_SPECS_COMPANY_REGISTRATION,
+			MaxValidity: 1 * YEAR,
+			Attributes: map[string]*DocumentAttribute{
+				"company_name":          requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"registration_number":   requiredAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"country":               requiredAttribute(models.ATTRIBUTE_TYPE_COUNTRY),
+				"incorporation_date":    requiredAttribute(models.ATTRIBUTE_TYPE_DATE),
+				"legal_form":            optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"address":               optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"vat_number":            optionalAttribute(models.ATTRIBUTE_TYPE_STRING),
+				"email":                 optionalAttribute(models.ATTRIBUTE_TYPE_EMAIL),
+				"phone":                 optionalAttribute(models.ATTRIBUTE_TYPE_PHONE),
+				"registration_document": optionalAttribute(models.ATTRIBUTE_TYPE_FILE),
+			},
+		},
+	}
+	documentSpecsMu sync.RWMutex
+)
+
+// RegisterDocumentSpec - Add or replace a document specs, safe to call while requests are validated
+func RegisterDocumentSpec(spec *DocumentSpec) {
+	documentSpecsMu.Lock()
+	defer documentSpecsMu.Unlock()
+	documentSpecs[spec.Specs] = spec
+}
+
+// GetDocumentSpec - Document specs known to the SDK, for verifiers to know what a document can prove
+func GetDocumentSpec(specs string) (*DocumentSpec, bool) {
+	documentSpecsMu.RLock()
+	defer documentSpecsMu.RUnlock()
+	spec, ok := documentSpecs[specs]
+	return spec, ok
+}
+
+// RequiredAttributes - Sorted names of attributes every document of this specs carries
+func (s *DocumentSpec) RequiredAttributes() []string {
+	return s.attributeNames(true)
+}
+
+// OptionalAttributes - Sorted names of attributes a document of this specs may carry
+func (s *DocumentSpec) OptionalAttributes() []string {
+	return s.attributeNames(false)
+}
+
+// CanProve - Whether a document of this specs can carry the attribute
+func (s *DocumentSpec) CanProve(name string) bool {
+	_, ok := s.Attributes[name]
+	return ok
+}
+
+func (s *DocumentSpec) attributeNames(required bool) []string {
+	names := []string{}
+	for name, attribute := range s.Attributes {
+		if attribute.Required == required {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ValidateDocument - Check document attributes and validity period against its specs
+//
+// Documents of specs not registered with RegisterDocumentSpec are issuer defined and left unchecked.
+func ValidateDocument(request *protocol.IssueRequest, now time.Time) error {
+	errs := FieldErrors{}
+	if request.Document == nil {
+		errs.add("document", "is required")
+		return errs
+	}
+
+	spec, ok := GetDocumentSpec(request.Document.Specs)
+	if !ok {
+		return nil
+	}
+
+	for _, name := range spec.RequiredAttributes() {
+		if _, ok := request.Document.Attributes[name]; !ok {
+			errs.add(fmt.Sprintf("document.attributes.%s", name), "is required by %s", spec.Specs)
+		}
+	}
+
+	names := make([]string, 0, len(request.Document.Attributes))
+	for name := range request.Document.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := fmt.Sprintf("document.attributes.%s", name)
+		attribute, ok := spec.Attributes[name]
+		if !ok {
+			errs.add(path, "is not part of %s", spec.Specs)
+			continue
+		}
+
+		document := request.Document.Attributes[name]
+		if document != nil && document.Content != nil && document.Content.Type != attribute.Type {
+			errs.add(path+".content.type", "must be %s", attribute.Type)
+		}
+	}
+
+	expiresAt := time.Unix(request.Document.ExpiresAt, 0)
+	if !expiresAt.After(now) {
+		errs.add("document.expires_at", "must be in the future")
+	} else if expiresAt.Sub(now) > spec.MaxValidity {
+		errs.add("document.expires_at", "exceeds maximum validity of %s", spec.MaxValidity)
+	}
+
+	return errs.err()
+}
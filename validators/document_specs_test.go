@@ -0,0 +1,148 @@
+package validators
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+var documentTime = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+// documentRequest - IssueRequest carrying the required attributes of specs, expiring after validity
+func documentRequest(t *testing.T, specs string, validity time.Duration) *protocol.IssueRequest {
+	t.Helper()
+	spec, ok := GetDocumentSpec(specs)
+	if !ok {
+		t.Fatalf("%s is not registered", specs)
+	}
+
+	attributes := make(map[string]*protocol.IssDocumentAttribute)
+	for _, name := range spec.RequiredAttributes() {
+		attributes[name] = &protocol.IssDocumentAttribute{Content: &protocol.IssDocumentAttributeContent{Type: spec.Attributes[name].Type}}
+	}
+
+	return &protocol.IssueRequest{
+		Document: &protocol.IssDocument{Specs: specs, ExpiresAt: documentTime.Add(validity).Unix(), Attributes: attributes},
+	}
+}
+
+func TestValidateDocumentRegisteredSpecs(t *testing.T) {
+	for _, specs := range []string{
+		models.DOCUMENT_SPECS_PASSPORT,
+		models.DOCUMENT_SPECS_NATIONAL_ID,
+		models.DOCUMENT_SPECS_DRIVER_LICENSE,
+		models.DOCUMENT_SPECS_PROOF_OF_ADDRESS,
+		models.DOCUMENT_SPECS_COMPANY_REGISTRATION,
+	} {
+		t.Run(specs, func(t *testing.T) {
+			spec, _ := GetDocumentSpec(specs)
+			if err := ValidateDocument(documentRequest(t, specs, spec.MaxValidity), documentTime); err != nil {
+				t.Errorf("document with required attributes at max validity: %v", err)
+			}
+
+			request := documentRequest(t, specs, spec.MaxValidity)
+			for _, name := range spec.OptionalAttributes() {
+				request.Document.Attributes[name] = &protocol.IssDocumentAttribute{Content: &protocol.IssDocumentAttributeContent{Type: spec.Attributes[name].Type}}
+			}
+			if err := ValidateDocument(request, documentTime); err != nil {
+				t.Errorf("document with every attribute: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateDocumentFieldErrors(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		change func(request *protocol.IssueRequest)
+		paths  []string
+	}{
+		{"missing required attribute", func(request *protocol.IssueRequest) {
+			delete(request.Document.Attributes, "lastname")
+			delete(request.Document.Attributes, "birth_date")
+		}, []string{"document.attributes.birth_date", "document.attributes.lastname"}},
+		{"unknown attribute", func(request *protocol.IssueRequest) {
+			request.Document.Attributes["favorite_color"] = &protocol.IssDocumentAttribute{}
+		}, []string{"document.attributes.favorite_color"}},
+		{"attribute type", func(request *protocol.IssueRequest) {
+			request.Document.Attributes["birth_date"].Content.Type = models.ATTRIBUTE_TYPE_STRING
+		}, []string{"document.attributes.birth_date.content.type"}},
+		{"expired", func(request *protocol.IssueRequest) {
+			request.Document.ExpiresAt = documentTime.Unix()
+		}, []string{"document.expires_at"}},
+		{"beyond max validity", func(request *protocol.IssueRequest) {
+			request.Document.ExpiresAt = documentTime.Add(10*YEAR + time.Second).Unix()
+		}, []string{"document.expires_at"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			request := documentRequest(t, models.DOCUMENT_SPECS_PASSPORT, YEAR)
+			test.change(request)
+
+			paths := fieldErrorPaths(t, ValidateDocument(request, documentTime))
+			if strings.Join(paths, ",") != strings.Join(test.paths, ",") {
+				t.Errorf("paths = %v, want %v", paths, test.paths)
+			}
+		})
+	}
+}
+
+func TestValidateDocumentLeavesUnregisteredSpecsUnchecked(t *testing.T) {
+	request := &protocol.IssueRequest{Document: &protocol.IssDocument{Specs: "anima:specs:document/membership@1.0.0"}}
+	if err := ValidateDocument(request, documentTime); err != nil {
+		t.Errorf("unregistered document specs checked: %v", err)
+	}
+}
+
+func TestDocumentSpecAttributes(t *testing.T) {
+	spec, ok := GetDocumentSpec(models.DOCUMENT_SPECS_PROOF_OF_ADDRESS)
+	if !ok {
+		t.Fatal("proof of address is not registered")
+	}
+
+	required := spec.RequiredAttributes()
+	if want := []string{"address", "country", "firstname", "issued_date", "lastname"}; strings.Join(required, ",") != strings.Join(want, ",") {
+		t.Errorf("required = %v, want %v", required, want)
+	}
+
+	optional := spec.OptionalAttributes()
+	if !sort.StringsAreSorted(optional) || len(optional)+len(required) != len(spec.Attributes) {
+		t.Errorf("optional = %v", optional)
+	}
+
+	if !spec.CanProve("postal_code") || spec.CanProve("birth_date") {
+		t.Error("CanProve does not match the specs attributes")
+	}
+}
+
+func TestRegisterDocumentSpecWhileValidating(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		specs := fmt.Sprintf("anima:specs:document/test_%d@1.0.0", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterDocumentSpec(&DocumentSpec{
+				Specs:       specs,
+				MaxValidity: YEAR,
+				Attributes:  map[string]*DocumentAttribute{"member_id": requiredAttribute(models.ATTRIBUTE_TYPE_STRING)},
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			ValidateDocument(&protocol.IssueRequest{Document: &protocol.IssDocument{Specs: specs}}, documentTime)
+		}()
+	}
+	wg.Wait()
+
+	request := &protocol.IssueRequest{Document: &protocol.IssDocument{Specs: "anima:specs:document/test_0@1.0.0", ExpiresAt: documentTime.Add(YEAR).Unix()}}
+	paths := fieldErrorPaths(t, ValidateDocument(request, documentTime))
+	if len(paths) != 1 || paths[0] != "document.attributes.member_id" {
+		t.Errorf("paths = %v, want registered spec enforced", paths)
+	}
+}
@@ -0,0 +1,48 @@
+package validators
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+// FieldError - Validation problem of a single request field
+type FieldError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+func (e *FieldError) Is(target error) bool {
+	return target == models.ErrInvalidRequest
+}
+
+// FieldErrors - Every validation problem found in a request
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e FieldErrors) Is(target error) bool {
+	return target == models.ErrInvalidRequest
+}
+
+func (e *FieldErrors) add(path string, format string, args ...interface{}) {
+	*e = append(*e, &FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (e FieldErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,136 @@
+package validators
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// JSONSchema - Subset of JSON Schema used by proof specs
+type JSONSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	Minimum              *float64               `json:"minimum,omitempty"`
+	Maximum              *float64               `json:"maximum,omitempty"`
+}
+
+// MustParseJSONSchema - Parse a JSON schema declared in code
+func MustParseJSONSchema(schema string) *JSONSchema {
+	s := JSONSchema{}
+	if err := json.Unmarshal([]byte(schema), &s); err != nil {
+		panic(fmt.Sprintf("invalid json schema: %v", err))
+	}
+	return &s
+}
+
+// Validate - Check a decoded JSON value against the schema
+func (s *JSONSchema) Validate(path string, value interface{}, errs *FieldErrors) {
+	if len(s.Enum) > 0 && !inEnum(value, s.Enum) {
+		errs.add(path, "must be one of %v", s.Enum)
+		return
+	}
+
+	switch s.Type {
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			errs.add(path, "must be an object")
+			return
+		}
+
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				errs.add(path+"."+name, "is required")
+			}
+		}
+
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := s.Properties[name]
+			if !ok {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					errs.add(path+"."+name, "is not allowed")
+				}
+				continue
+			}
+			property.Validate(path+"."+name, object[name], errs)
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			errs.add(path, "must be an array")
+			return
+		}
+
+		if s.MinItems != nil && len(items) < *s.MinItems {
+			errs.add(path, "must have at least %d items", *s.MinItems)
+		}
+
+		if s.Items != nil {
+			for i, item := range items {
+				s.Items.Validate(fmt.Sprintf("%s.%d", path, i), item, errs)
+			}
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			errs.add(path, "must be a string")
+			return
+		}
+
+		if s.MinLength != nil && utf8.RuneCountInString(str) < *s.MinLength {
+			errs.add(path, "must have at least %d characters", *s.MinLength)
+		}
+
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339, str); err != nil {
+				errs.add(path, "must be an RFC 3339 date-time")
+			}
+		}
+	case "number", "integer":
+		n, ok := value.(float64)
+		if !ok {
+			errs.add(path, "must be a number")
+			return
+		}
+
+		if s.Type == "integer" && n != float64(int64(n)) {
+			errs.add(path, "must be an integer")
+		}
+
+		if s.Minimum != nil && n < *s.Minimum {
+			errs.add(path, "must be >= %v", *s.Minimum)
+		}
+
+		if s.Maximum != nil && n > *s.Maximum {
+			errs.add(path, "must be <= %v", *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			errs.add(path, "must be a boolean")
+		}
+	}
+}
+
+func inEnum(value interface{}, enum []interface{}) bool {
+	for _, item := range enum {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package validators
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+// ProofSpec - Typed and validated payload of an IssProof specs
+type ProofSpec struct {
+	Specs   string
+	Version string
+	Schema  *JSONSchema
+	// New - Allocate the Go type the proof content decodes into
+	New func() interface{}
+}
+
+var (
+	proofSpecs = map[string]*ProofSpec{
+		models.PROOF_SPECS_DOCUMENT_SCAN: {
+			Specs:   models.PROOF_SPECS_DOCUMENT_SCAN,
+			Version: "1.0.0",
+			New:     func() interface{} { return &models.DocumentScanProof{} },
+			Schema: MustParseJSONSchema(`{
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["provider", "document_type", "issuing_country", "pages", "mrz_valid", "scanned_at"],
+				"properties": {
+					"provider": {"type": "string", "minLength": 1},
+					"document_type": {"type": "string", "enum": ["passport", "national_id", "driver_license", "residence_permit"]},
+					"issuing_country": {"type": "string", "minLength": 2},
+					"pages": {"type": "array", "minItems": 1, "items": {"type": "string", "minLength": 1}},
+					"mrz_valid": {"type": "boolean"},
+					"chip_verified": {"type": "boolean"},
+					"scanned_at": {"type": "integer", "minimum": 0}
+				}
+			}`),
+		},
+		models.PROOF_SPECS_LIVENESS: {
+			Specs:   models.PROOF_SPECS_LIVENESS,
+			Version: "1.0.0",
+			New:     func() interface{} { return &models.LivenessCheckProof{} },
+			Schema: MustParseJSONSchema(`{
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["provider", "method", "score", "passed", "checked_at"],
+				"properties": {
+					"provider": {"type": "string", "minLength": 1},
+					"method": {"type": "string", "enum": ["passive", "active"]},
+					"score": {"type": "number", "minimum": 0, "maximum": 1},
+					"face_match_score": {"type": "number", "minimum": 0, "maximum": 1},
+					"passed": {"type": "boolean"},
+					"checked_at": {"type": "integer", "minimum": 0}
+				}
+			}`),
+		},
+		models.PROOF_SPECS_MANUAL_REVIEW: {
+			Specs:   models.PROOF_SPECS_MANUAL_REVIEW,
+			Version: "1.0.0",
+			New:     func() interface{} { return &models.ManualReviewProof{} },
+			Schema: MustParseJSONSchema(`{
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["reviewer", "decision", "reviewed_at"],
+				"properties": {
+					"reviewer": {"type": "string", "minLength": 1},
+					"decision": {"type": "string", "enum": ["approved", "rejected"]},
+					"notes": {"type": "string"},
+					"reviewed_at": {"type": "integer", "minimum": 0}
+				}
+			}`),
+		},
+		models.PROOF_SPECS_KYC_REPORT: {
+			Specs:   models.PROOF_SPECS_KYC_REPORT,
+			Version: "1.0.0",
+			New:     func() interface{} { return &models.KYCReportProof{} },
+			Schema: MustParseJSONSchema(`{
+				"type": "object",
+				"additionalProperties": false,
+				"required": ["provider", "report_id", "level", "result", "checks", "completed_at"],
+				"properties": {
+					"provider": {"type": "string", "minLength": 1},
+					"report_id": {"type": "string", "minLength": 1},
+					"level": {"type": "string", "enum": ["basic", "standard", "enhanced"]},
+					"result": {"type": "string", "enum": ["clear", "consider", "rejected"]},
+					"checks": {"type": "array", "items": {"type": "string", "minLength": 1}},
+					"completed_at": {"type": "integer", "minimum": 0}
+				}
+			}`),
+		},
+	}
+	proofSpecsMu sync.RWMutex
+)
+
+// RegisterProofSpec - Add or replace a proof specs, safe to call while requests are validated
+func RegisterProofSpec(spec *ProofSpec) {
+	proofSpecsMu.Lock()
+	defer proofSpecsMu.Unlock()
+	proofSpecs[spec.Specs] = spec
+}
+
+// GetProofSpec - Registered proof specs
+func GetProofSpec(specs string) (*ProofSpec, bool) {
+	proofSpecsMu.RLock()
+	defer proofSpecsMu.RUnlock()
+	spec, ok := proofSpecs[specs]
+	return spec, ok
+}
+
+// ValidateProof - Validate proof content against its specs and decode it into its Go type
+//
+// Proofs of specs not registered with RegisterProofSpec are issuer defined, left unchecked and decoded to nil.
+func ValidateProof(proof *protocol.IssProof) (interface{}, error) {
+	errs := FieldErrors{}
+	if proof == nil {
+		errs.add("proof", "is required")
+		return nil, errs
+	}
+
+	spec, ok := GetProofSpec(proof.Specs)
+	if !ok {
+		return nil, nil
+	}
+
+	content, err := base64.StdEncoding.DecodeString(proof.Content)
+	if err != nil {
+		errs.add("proof.content", "invalid base64: %v", err)
+		return nil, errs
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(content, &value); err != nil {
+		errs.add("proof.content", "invalid json: %v", err)
+		return nil, errs
+	}
+
+	spec.Schema.Validate("proof.content", value, &errs)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+
+	typed := spec.New()
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(typed); err != nil {
+		errs.add("proof.content", "%v", err)
+		return nil, errs
+	}
+
+	return typed, nil
+}
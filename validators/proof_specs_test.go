@@ -0,0 +1,149 @@
+package validators
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+func proof(specs string, content string) *protocol.IssProof {
+	return &protocol.IssProof{Specs: specs, Content: base64.StdEncoding.EncodeToString([]byte(content))}
+}
+
+func TestValidateProofDecodesRegisteredSpecs(t *testing.T) {
+	for _, test := range []struct {
+		specs   string
+		content string
+		want    interface{}
+	}{
+		{
+			models.PROOF_SPECS_DOCUMENT_SCAN,
+			`{"provider":"scanner","document_type":"passport","issuing_country":"FR","pages":["page-1"],"mrz_valid":true,"scanned_at":1717243200}`,
+			&models.DocumentScanProof{Provider: "scanner", DocumentType: "passport", IssuingCountry: "FR", Pages: []string{"page-1"}, MrzValid: true, ScannedAt: 1717243200},
+		},
+		{
+			models.PROOF_SPECS_LIVENESS,
+			`{"provider":"liveness","method":"passive","score":0.98,"face_match_score":0.91,"passed":true,"checked_at":1717243200}`,
+			&models.LivenessCheckProof{Provider: "liveness", Method: "passive", Score: 0.98, FaceMatchScore: 0.91, Passed: true, CheckedAt: 1717243200},
+		},
+		{
+			models.PROOF_SPECS_MANUAL_REVIEW,
+			`{"reviewer":"reviewer","decision":"approved","reviewed_at":1717243200}`,
+			&models.ManualReviewProof{Reviewer: "reviewer", Decision: "approved", ReviewedAt: 1717243200},
+		},
+		{
+			models.PROOF_SPECS_KYC_REPORT,
+			`{"provider":"kyc","report_id":"r-1","level":"standard","result":"clear","checks":["aml","pep"],"completed_at":1717243200}`,
+			&models.KYCReportProof{Provider: "kyc", ReportID: "r-1", Level: "standard", Result: "clear", Checks: []string{"aml", "pep"}, CompletedAt: 1717243200},
+		},
+	} {
+		t.Run(test.specs, func(t *testing.T) {
+			typed, err := ValidateProof(proof(test.specs, test.content))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(typed, test.want) {
+				t.Errorf("proof = %+v, want %+v", typed, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateProofRejectsInvalidContent(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		specs   string
+		content string
+		paths   []string
+	}{
+		{"missing fields", models.PROOF_SPECS_MANUAL_REVIEW, `{"reviewer":"reviewer"}`, []string{"proof.content.decision", "proof.content.reviewed_at"}},
+		{"unknown field", models.PROOF_SPECS_MANUAL_REVIEW, `{"reviewer":"reviewer","decision":"approved","reviewed_at":1,"score":1}`, []string{"proof.content.score"}},
+		{"enum", models.PROOF_SPECS_MANUAL_REVIEW, `{"reviewer":"reviewer","decision":"maybe","reviewed_at":1}`, []string{"proof.content.decision"}},
+		{"empty string", models.PROOF_SPECS_MANUAL_REVIEW, `{"reviewer":"","decision":"approved","reviewed_at":1}`, []string{"proof.content.reviewer"}},
+		{"negative integer", models.PROOF_SPECS_MANUAL_REVIEW, `{"reviewer":"reviewer","decision":"approved","reviewed_at":-1}`, []string{"proof.content.reviewed_at"}},
+		{"fractional integer", models.PROOF_SPECS_KYC_REPORT, `{"provider":"kyc","report_id":"r-1","level":"basic","result":"clear","checks":[],"completed_at":1.5}`, []string{"proof.content.completed_at"}},
+		{"score above maximum", models.PROOF_SPECS_LIVENESS, `{"provider":"liveness","method":"active","score":1.5,"passed":true,"checked_at":1}`, []string{"proof.content.score"}},
+		{"empty array", models.PROOF_SPECS_DOCUMENT_SCAN, `{"provider":"scanner","document_type":"passport","issuing_country":"FR","pages":[],"mrz_valid":true,"scanned_at":1}`, []string{"proof.content.pages"}},
+		{"array item", models.PROOF_SPECS_DOCUMENT_SCAN, `{"provider":"scanner","document_type":"passport","issuing_country":"FR","pages":["page-1",""],"mrz_valid":true,"scanned_at":1}`, []string{"proof.content.pages.1"}},
+		{"wrong type", models.PROOF_SPECS_DOCUMENT_SCAN, `{"provider":"scanner","document_type":"passport","issuing_country":"FR","pages":["page-1"],"mrz_valid":"yes","scanned_at":1}`, []string{"proof.content.mrz_valid"}},
+		{"not an object", models.PROOF_SPECS_MANUAL_REVIEW, `["approved"]`, []string{"proof.content"}},
+		{"invalid json", models.PROOF_SPECS_MANUAL_REVIEW, `{"reviewer":`, []string{"proof.content"}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			typed, err := ValidateProof(proof(test.specs, test.content))
+			if typed != nil {
+				t.Errorf("invalid proof decoded to %+v", typed)
+			}
+
+			paths := fieldErrorPaths(t, err)
+			if strings.Join(paths, ",") != strings.Join(test.paths, ",") {
+				t.Errorf("paths = %v, want %v", paths, test.paths)
+			}
+		})
+	}
+}
+
+func TestValidateProofEnvelope(t *testing.T) {
+	_, err := ValidateProof(nil)
+	if paths := fieldErrorPaths(t, err); len(paths) != 1 || paths[0] != "proof" {
+		t.Errorf("nil proof paths = %v", paths)
+	}
+
+	_, err = ValidateProof(&protocol.IssProof{Specs: models.PROOF_SPECS_MANUAL_REVIEW, Content: "not base64!"})
+	if paths := fieldErrorPaths(t, err); len(paths) != 1 || paths[0] != "proof.content" {
+		t.Errorf("invalid base64 paths = %v", paths)
+	}
+}
+
+func TestValidateProofLeavesUnregisteredSpecsUnchecked(t *testing.T) {
+	typed, err := ValidateProof(proof("anima:specs:proof/issuer_defined@1.0.0", `not even json`))
+	if typed != nil || err != nil {
+		t.Errorf("ValidateProof = %v, %v, want nil, nil", typed, err)
+	}
+}
+
+type testProof struct {
+	Level int `json:"level"`
+}
+
+func TestRegisterProofSpecWhileValidating(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		specs := fmt.Sprintf("anima:specs:proof/test_%d@1.0.0", i)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterProofSpec(&ProofSpec{
+				Specs:   specs,
+				Version: "1.0.0",
+				New:     func() interface{} { return &testProof{} },
+				Schema:  MustParseJSONSchema(`{"type": "object", "required": ["level"], "properties": {"level": {"type": "integer"}}}`),
+			})
+		}()
+		go func() {
+			defer wg.Done()
+			ValidateProof(proof(specs, `{"level":1}`))
+		}()
+	}
+	wg.Wait()
+
+	typed, err := ValidateProof(proof("anima:specs:proof/test_0@1.0.0", `{"level":2}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(typed, &testProof{Level: 2}) {
+		t.Errorf("proof = %+v", typed)
+	}
+
+	if _, err := ValidateProof(proof("anima:specs:proof/test_0@1.0.0", `{}`)); err == nil {
+		t.Error("registered spec not enforced")
+	}
+}
@@ -1,15 +1,22 @@
 package validators
 
 import (
-	"fmt"
-
 	"github.com/anima-protocol/anima-go/models"
 	"github.com/anima-protocol/anima-go/utils"
 )
 
+// ValidateProtocol - Check anima can sign requests for a supported chain
 func ValidateProtocol(anima *models.Protocol) error {
+	if anima == nil {
+		return models.NewError(models.ErrInvalidConfig, "anima protocol is required")
+	}
+
+	if anima.SigningFunc == nil {
+		return models.NewError(models.ErrInvalidConfig, "signing function is required")
+	}
+
 	if !utils.InArray(anima.Chain, models.AVAILABLE_CHAIN) {
-		return fmt.Errorf("chain unavailable")
+		return models.NewError(models.ErrUnsupportedChain, "chain unavailable: %s", anima.Chain)
 	}
 	return nil
 }
@@ -0,0 +1,29 @@
+package validators
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anima-protocol/anima-go/models"
+)
+
+func TestValidateProtocol(t *testing.T) {
+	signingFunc := func([]byte) (string, error) { return "", nil }
+	for _, test := range []struct {
+		name  string
+		anima *models.Protocol
+		kind  error
+	}{
+		{"valid", &models.Protocol{Chain: models.CHAIN_ETH, SigningFunc: signingFunc}, nil},
+		{"nil protocol", nil, models.ErrInvalidConfig},
+		{"nil signing function", &models.Protocol{Chain: models.CHAIN_ETH}, models.ErrInvalidConfig},
+		{"chain", &models.Protocol{Chain: "SOL", SigningFunc: signingFunc}, models.ErrUnsupportedChain},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateProtocol(test.anima)
+			if !errors.Is(err, test.kind) {
+				t.Errorf("err = %v, want %v", err, test.kind)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package validators
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sort"
+
+	"github.com/anima-protocol/anima-go/models"
+	"github.com/anima-protocol/anima-go/protocol"
+)
+
+// ValidateIssueRequest - Check every field an IssueRequest needs before being signed
+func ValidateIssueRequest(request *protocol.IssueRequest) error {
+	errs := FieldErrors{}
+	if request == nil {
+		errs.add("request", "is required")
+		return errs
+	}
+
+	if request.Document == nil {
+		errs.add("document", "is required")
+	} else {
+		validateIssDocument("document", request.Document, &errs)
+	}
+
+	names := make([]string, 0, len(request.Attributes))
+	for name := range request.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		path := fmt.Sprintf("attributes.%s", name)
+		if request.Attributes[name] == nil {
+			errs.add(path, "is required")
+			continue
+		}
+
+		if request.Document != nil {
+			if _, ok := request.Document.Attributes[name]; !ok {
+				errs.add(path, "is not a document attribute")
+			}
+		}
+	}
+
+	if request.Proof == nil {
+		errs.add("proof", "is required")
+	} else {
+		requireString("proof.specs", request.Proof.Specs, &errs)
+		requireBase64("proof.content", request.Proof.Content, &errs)
+	}
+
+	return errs.err()
+}
+
+func validateIssDocument(path string, document *protocol.IssDocument, errs *FieldErrors) {
+	requireString(path+".specs", document.Specs, errs)
+
+	if document.ExpiresAt <= 0 {
+		errs.add(path+".expires_at", "is required")
+	}
+
+	if len(document.Attributes) == 0 {
+		errs.add(path+".attributes", "is required")
+	}
+
+	names := make([]string, 0, len(document.Attributes))
+	for name := range document.Attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		attrPath := fmt.Sprintf("%s.attributes.%s", path, name)
+		attribute := document.Attributes[name]
+		if attribute == nil {
+			errs.add(attrPath, "is required")
+			continue
+		}
+
+		if attribute.Content == nil {
+			errs.add(attrPath+".content", "is required")
+			continue
+		}
+
+		requireString(attrPath+".content.type", attribute.Content.Type, errs)
+		if attribute.Content.Type != models.ATTRIBUTE_TYPE_FILE {
+			requireString(attrPath+".content.value", attribute.Content.Value, errs)
+		}
+		if attribute.Content.Name != "" && attribute.Content.Name != name {
+			errs.add(attrPath+".content.name", "must be %q", name)
+		}
+	}
+
+	if document.Authorization == nil {
+		errs.add(path+".authorization", "is required")
+		return
+	}
+
+	requireString(path+".authorization.specs", document.Authorization.Specs, errs)
+	requireBase64(path+".authorization.content", document.Authorization.Content, errs)
+	requireString(path+".authorization.signature", document.Authorization.Signature, errs)
+}
+
+// ValidateIssuer - Check the issuer signing an IssueRequest
+func ValidateIssuer(issuer *protocol.AnimaIssuer) error {
+	errs := FieldErrors{}
+	if issuer == nil {
+		errs.add("issuer", "is required")
+		return errs
+	}
+
+	requireString("issuer.id", issuer.Id, &errs)
+	requireString("issuer.public_address", issuer.PublicAddress, &errs)
+	requireString("issuer.chain", issuer.Chain, &errs)
+
+	return errs.err()
+}
+
+// ValidateVerifyRequest - Check every field of a VerifyRequest
+func ValidateVerifyRequest(request *protocol.VerifyRequest) error {
+	errs := FieldErrors{}
+	if request == nil {
+		errs.add("request", "is required")
+		return errs
+	}
+
+	if request.Authorization == nil {
+		errs.add("authorization", "is required")
+		return errs
+	}
+
+	requireString("authorization.specs", request.Authorization.Specs, &errs)
+	requireBase64("authorization.content", request.Authorization.Content, &errs)
+	requireString("authorization.signature", request.Authorization.Signature, &errs)
+
+	return errs.err()
+}
+
+// ValidateRegisterVerifierRequest - Check every field of a RegisterVerifierRequest
+func ValidateRegisterVerifierRequest(request *protocol.RegisterVerifierRequest) error {
+	errs := FieldErrors{}
+	if request == nil {
+		errs.add("request", "is required")
+		return errs
+	}
+
+	requireString("id", request.Id, &errs)
+	requireString("public_address", request.PublicAddress, &errs)
+	requireString("chain", request.Chain, &errs)
+
+	return errs.err()
+}
+
+func requireString(path string, value string, errs *FieldErrors) bool {
+	if value == "" {
+		errs.add(path, "is required")
+		return false
+	}
+	return true
+}
+
+func requireBase64(path string, value string, errs *FieldErrors) {
+	if !requireString(path, value, errs) {
+		return
+	}
+
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		errs.add(path, "must be base64 encoded")
+	}
+}